@@ -0,0 +1,125 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Acl is an acl line of a frontend or backend, addressed by its index within the section
+type Acl struct {
+	AclName   *string `json:"acl_name,omitempty"`
+	Criterion *string `json:"criterion,omitempty"`
+	Value     *string `json:"value,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (a Acl) MarshalJSON() ([]byte, error) {
+	type plain Acl
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (a *Acl) UnmarshalJSON(data []byte) error {
+	type plain Acl
+	extra, err := unmarshalWithExtra(data, (*plain)(a))
+	if err != nil {
+		return err
+	}
+
+	a.Extra = extra
+	return nil
+}
+
+// AddAcl inserts the acl at index; the acls from index onwards move down by one
+func (cfg *Configuration) AddAcl(ctx context.Context, parentType string, parentName string, index int, acl Acl) (*Acl, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/acls/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(acl)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsAcl(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetAcl(ctx context.Context, parentType string, parentName string, index int) (*Acl, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/acls/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsAcl(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListAcls(ctx context.Context, parentType string, parentName string) ([]Acl, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/acls", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsAcls(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceAcl(ctx context.Context, parentType string, parentName string, index int, acl Acl) (*Acl, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/acls/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(acl)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsAcl(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllAcls replaces every acl of the section with acls, in order
+func (cfg *Configuration) ReplaceAllAcls(ctx context.Context, parentType string, parentName string, acls []Acl) ([]Acl, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/acls", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(acls)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsAcls(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteAcl(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/acls/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsAcl(ctx context.Context, apiUrl string, method string, body io.Reader) (*Acl, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult Acl
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsAcls(ctx context.Context, apiUrl string, method string, body io.Reader) ([]Acl, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Acl
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
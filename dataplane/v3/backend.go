@@ -2,6 +2,7 @@ package v3
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,27 +11,389 @@ import (
 const (
 	BACKEND_BALANCE_ALGORITHM_FIRST      = "first"
 	BACKEND_BALANCE_ALGORITHM_HASH       = "hash"
+	BACKEND_BALANCE_ALGORITHM_HDR        = "hdr"
+	BACKEND_BALANCE_ALGORITHM_LEASTCONN  = "leastconn"
 	BACKEND_BALANCE_ALGORITHM_RANDOM     = "random"
+	BACKEND_BALANCE_ALGORITHM_RDP_COOKIE = "rdp-cookie"
 	BACKEND_BALANCE_ALGORITHM_ROUNDROBIN = "roundrobin"
+	BACKEND_BALANCE_ALGORITHM_SOURCE     = "source"
+	BACKEND_BALANCE_ALGORITHM_STATIC_RR  = "static-rr"
+	BACKEND_BALANCE_ALGORITHM_URI        = "uri"
+	BACKEND_BALANCE_ALGORITHM_URL_PARAM  = "url_param"
 )
 
 const (
-	BACKEND_MODE_TCP = "tcp"
+	BACKEND_MODE_HTTP = "http"
+	BACKEND_MODE_TCP  = "tcp"
+)
+
+const (
+	BACKEND_ADV_CHECK_HTTPCHK       = "httpchk"
+	BACKEND_ADV_CHECK_LDAP_CHECK    = "ldap-check"
+	BACKEND_ADV_CHECK_MYSQL_CHECK   = "mysql-check"
+	BACKEND_ADV_CHECK_PGSQL_CHECK   = "pgsql-check"
+	BACKEND_ADV_CHECK_REDIS_CHECK   = "redis-check"
+	BACKEND_ADV_CHECK_SMTPCHK       = "smtpchk"
+	BACKEND_ADV_CHECK_SSL_HELLO_CHK = "ssl-hello-chk"
+	BACKEND_ADV_CHECK_TCP_CHECK     = "tcp-check"
+)
+
+const (
+	BACKEND_COOKIE_TYPE_INSERT  = "insert"
+	BACKEND_COOKIE_TYPE_PREFIX  = "prefix"
+	BACKEND_COOKIE_TYPE_REWRITE = "rewrite"
+)
+
+const (
+	BACKEND_HASH_TYPE_METHOD_CONSISTENT = "consistent"
+	BACKEND_HASH_TYPE_METHOD_MAP_BASED  = "map-based"
+)
+
+const (
+	HTTP_CONNECTION_MODE_HTTPCLOSE         = "httpclose"
+	HTTP_CONNECTION_MODE_HTTP_SERVER_CLOSE = "http-server-close"
+	HTTP_CONNECTION_MODE_HTTP_KEEP_ALIVE   = "http-keep-alive"
+)
+
+const (
+	STICK_TABLE_TYPE_BINARY  = "binary"
+	STICK_TABLE_TYPE_INTEGER = "integer"
+	STICK_TABLE_TYPE_IP      = "ip"
+	STICK_TABLE_TYPE_IPV6    = "ipv6"
+	STICK_TABLE_TYPE_STRING  = "string"
 )
 
 type BackendBalance struct {
-	Algorithm string `json:"algorithm,omitempty"`
+	Algorithm         string  `json:"algorithm,omitempty"`
+	HashExpression    *string `json:"hash_expression,omitempty"`
+	HdrName           *string `json:"hdr_name,omitempty"`
+	HdrUseDomainOnly  *bool   `json:"hdr_use_domain_only,omitempty"`
+	RandomDraws       *int    `json:"random_draws,omitempty"`
+	RdpCookieName     *string `json:"rdp_cookie_name,omitempty"`
+	UriDepth          *int    `json:"uri_depth,omitempty"`
+	UriLen            *int    `json:"uri_len,omitempty"`
+	UriPathOnly       *bool   `json:"uri_path_only,omitempty"`
+	UriWhole          *bool   `json:"uri_whole,omitempty"`
+	UrlParam          *string `json:"url_param,omitempty"`
+	UrlParamCheckPost *int    `json:"url_param_check_post,omitempty"`
+	UrlParamMaxWait   *int    `json:"url_param_max_wait,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b BackendBalance) MarshalJSON() ([]byte, error) {
+	type plain BackendBalance
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *BackendBalance) UnmarshalJSON(data []byte) error {
+	type plain BackendBalance
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+// BackendHttpchkParams holds the request sent by "option httpchk"
+type BackendHttpchkParams struct {
+	Host    *string `json:"host,omitempty"`
+	Method  *string `json:"method,omitempty"`
+	Uri     *string `json:"uri,omitempty"`
+	Version *string `json:"version,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b BackendHttpchkParams) MarshalJSON() ([]byte, error) {
+	type plain BackendHttpchkParams
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *BackendHttpchkParams) UnmarshalJSON(data []byte) error {
+	type plain BackendHttpchkParams
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+type CookieValue struct {
+	Value string `json:"value"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (c CookieValue) MarshalJSON() ([]byte, error) {
+	type plain CookieValue
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *CookieValue) UnmarshalJSON(data []byte) error {
+	type plain CookieValue
+	extra, err := unmarshalWithExtra(data, (*plain)(c))
+	if err != nil {
+		return err
+	}
+
+	c.Extra = extra
+	return nil
+}
+
+// BackendCookie configures cookie based persistence
+type BackendCookie struct {
+	Name     *string       `json:"name,omitempty"`
+	Attr     []CookieValue `json:"attr,omitempty"`
+	Domain   []CookieValue `json:"domain,omitempty"`
+	Dynamic  *bool         `json:"dynamic,omitempty"`
+	Httponly *bool         `json:"httponly,omitempty"`
+	Indirect *bool         `json:"indirect,omitempty"`
+	Maxidle  *int          `json:"maxidle,omitempty"`
+	Maxlife  *int          `json:"maxlife,omitempty"`
+	Nocache  *bool         `json:"nocache,omitempty"`
+	Postonly *bool         `json:"postonly,omitempty"`
+	Preserve *bool         `json:"preserve,omitempty"`
+	Secure   *bool         `json:"secure,omitempty"`
+	Type     *string       `json:"type,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b BackendCookie) MarshalJSON() ([]byte, error) {
+	type plain BackendCookie
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *BackendCookie) UnmarshalJSON(data []byte) error {
+	type plain BackendCookie
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+// Forwardfor configures "option forwardfor"; Enabled must be OPTION_ENABLED
+type Forwardfor struct {
+	Enabled *string `json:"enabled,omitempty"`
+	Except  *string `json:"except,omitempty"`
+	Header  *string `json:"header,omitempty"`
+	Ifnone  *bool   `json:"ifnone,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (f Forwardfor) MarshalJSON() ([]byte, error) {
+	type plain Forwardfor
+	return marshalWithExtra(plain(f), f.Extra)
+}
+
+func (f *Forwardfor) UnmarshalJSON(data []byte) error {
+	type plain Forwardfor
+	extra, err := unmarshalWithExtra(data, (*plain)(f))
+	if err != nil {
+		return err
+	}
+
+	f.Extra = extra
+	return nil
 }
 
+type BackendHashType struct {
+	Function *string `json:"function,omitempty"`
+	Method   *string `json:"method,omitempty"`
+	Modifier *string `json:"modifier,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b BackendHashType) MarshalJSON() ([]byte, error) {
+	type plain BackendHashType
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *BackendHashType) UnmarshalJSON(data []byte) error {
+	type plain BackendHashType
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+// BackendRedispatch configures "option redispatch"; Enabled is OPTION_ENABLED or OPTION_DISABLED
+type BackendRedispatch struct {
+	Enabled  *string `json:"enabled,omitempty"`
+	Interval *int    `json:"interval,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b BackendRedispatch) MarshalJSON() ([]byte, error) {
+	type plain BackendRedispatch
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *BackendRedispatch) UnmarshalJSON(data []byte) error {
+	type plain BackendRedispatch
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+type StickTable struct {
+	Expire   *int    `json:"expire,omitempty"`
+	Keylen   *int    `json:"keylen,omitempty"`
+	Nopurge  *bool   `json:"nopurge,omitempty"`
+	Peers    *string `json:"peers,omitempty"`
+	RecvOnly *bool   `json:"recv_only,omitempty"`
+	Size     *int    `json:"size,omitempty"`
+	Srvkey   *string `json:"srvkey,omitempty"`
+	Store    *string `json:"store,omitempty"`
+	Type     *string `json:"type,omitempty"`
+	WriteTo  *string `json:"write_to,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (s StickTable) MarshalJSON() ([]byte, error) {
+	type plain StickTable
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (s *StickTable) UnmarshalJSON(data []byte) error {
+	type plain StickTable
+	extra, err := unmarshalWithExtra(data, (*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	s.Extra = extra
+	return nil
+}
+
+// DefaultServer holds the "default-server" parameters applied to every server of a section
+type DefaultServer struct {
+	ServerParams
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (d DefaultServer) MarshalJSON() ([]byte, error) {
+	type plain DefaultServer
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+func (d *DefaultServer) UnmarshalJSON(data []byte) error {
+	type plain DefaultServer
+	extra, err := unmarshalWithExtra(data, (*plain)(d))
+	if err != nil {
+		return err
+	}
+
+	d.Extra = extra
+	return nil
+}
+
+// Backend is a backend section. Timeouts are in milliseconds.
+// Fields left nil are removed from the section by ReplaceBackend, so replace a backend obtained from GetBackend.
 type Backend struct {
-	Id      *int            `json:"id,omitempty"`
-	Balance *BackendBalance `json:"balance,omitempty"`
-	Name    *string         `json:"name,omitempty"`
-	Mode    string          `json:"mode,omitempty"`
+	Id          *int            `json:"id,omitempty"`
+	Balance     *BackendBalance `json:"balance,omitempty"`
+	Name        *string         `json:"name,omitempty"`
+	Mode        string          `json:"mode,omitempty"`
+	From        *string         `json:"from,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Disabled    *bool           `json:"disabled,omitempty"`
+	Enabled     *bool           `json:"enabled,omitempty"`
+
+	CheckTimeout         *int `json:"check_timeout,omitempty"`
+	ConnectTimeout       *int `json:"connect_timeout,omitempty"`
+	HttpKeepAliveTimeout *int `json:"http_keep_alive_timeout,omitempty"`
+	HttpRequestTimeout   *int `json:"http_request_timeout,omitempty"`
+	QueueTimeout         *int `json:"queue_timeout,omitempty"`
+	ServerTimeout        *int `json:"server_timeout,omitempty"`
+	ServerFinTimeout     *int `json:"server_fin_timeout,omitempty"`
+	TarpitTimeout        *int `json:"tarpit_timeout,omitempty"`
+	TunnelTimeout        *int `json:"tunnel_timeout,omitempty"`
+
+	AdvCheck        *string               `json:"adv_check,omitempty"`
+	HttpchkParams   *BackendHttpchkParams `json:"httpchk_params,omitempty"`
+	Checkcache      *string               `json:"checkcache,omitempty"`
+	LogHealthChecks *string               `json:"log_health_checks,omitempty"`
+	ExternalCheck   *string               `json:"external_check,omitempty"`
+
+	Abortonclose       *string     `json:"abortonclose,omitempty"`
+	Forwardfor         *Forwardfor `json:"forwardfor,omitempty"`
+	HttpBufferRequest  *string     `json:"http-buffer-request,omitempty"`
+	HttpConnectionMode *string     `json:"http_connection_mode,omitempty"`
+	HttpReuse          *string     `json:"http_reuse,omitempty"`
+	HttpSendNameHeader *string     `json:"http_send_name_header,omitempty"`
+	Tcpka              *string     `json:"tcpka,omitempty"`
+	Srvtcpka           *string     `json:"srvtcpka,omitempty"`
+
+	Cookie        *BackendCookie     `json:"cookie,omitempty"`
+	DefaultServer *DefaultServer     `json:"default_server,omitempty"`
+	HashType      *BackendHashType   `json:"hash_type,omitempty"`
+	StickTable    *StickTable        `json:"stick_table,omitempty"`
+	Redispatch    *BackendRedispatch `json:"redispatch,omitempty"`
+	Retries       *int               `json:"retries,omitempty"`
+	RetryOn       *string            `json:"retry_on,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (c Client) AddBackend(backend Backend, transactionId string) (*Backend, error) {
-	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/backends?transaction_id=%s", c.BaseUrl, transactionId)
+func (b Backend) MarshalJSON() ([]byte, error) {
+	type plain Backend
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *Backend) UnmarshalJSON(data []byte) error {
+	type plain Backend
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+func (c Client) AddBackend(ctx context.Context, backend Backend, transactionId string) (*Backend, error) {
+	return c.inTransaction(transactionId).AddBackend(ctx, backend)
+}
+
+func (c Client) GetBackend(ctx context.Context, name string, transactionId string) (*Backend, error) {
+	return c.inTransaction(transactionId).GetBackend(ctx, name)
+}
+
+func (c Client) ListBackends(ctx context.Context, transactionId string) ([]Backend, error) {
+	return c.inTransaction(transactionId).ListBackends(ctx)
+}
+
+func (c Client) ReplaceBackend(ctx context.Context, name string, backend Backend, transactionId string) (*Backend, error) {
+	return c.inTransaction(transactionId).ReplaceBackend(ctx, name, backend)
+}
+
+func (c Client) DeleteBackend(ctx context.Context, name string, transactionId string) error {
+	return c.inTransaction(transactionId).DeleteBackend(ctx, name)
+}
+
+func (cfg *Configuration) AddBackend(ctx context.Context, backend Backend) (*Backend, error) {
+	apiUrl := cfg.writeUrl("backends")
 
 	body, err := json.Marshal(backend)
 	if err != nil {
@@ -39,26 +402,21 @@ func (c Client) AddBackend(backend Backend, transactionId string) (*Backend, err
 		}
 	}
 
-	return c.executeApiReturnsBackend(apiUrl, "POST", bytes.NewReader(body))
+	return cfg.executeApiReturnsBackend(ctx, apiUrl, "POST", bytes.NewReader(body))
 }
 
-func (c Client) GetBackend(name string, transactionId string) (*Backend, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s?transaction_id=%s",
-		c.BaseUrl,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) GetBackend(ctx context.Context, name string) (*Backend, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("backends/%s", name))
 
-	return c.executeApiReturnsBackend(apiUrl, "GET", nil)
+	return cfg.executeApiReturnsBackend(ctx, apiUrl, "GET", nil)
 }
 
-func (c Client) ListBackends(transactionId string) ([]Backend, error) {
-	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/backends?transaction_id=%s", c.BaseUrl, transactionId)
+func (cfg *Configuration) ListBackends(ctx context.Context) ([]Backend, error) {
+	apiUrl := cfg.readUrl("backends")
 
-	resTxt, err := c.callApi(apiUrl, "GET", nil)
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
 	if err != nil {
-		return nil, fmt.Errorf("invalid response payload")
+		return nil, err
 	}
 
 	if len(string(resTxt)) == 0 {
@@ -73,36 +431,26 @@ func (c Client) ListBackends(transactionId string) ([]Backend, error) {
 	return resResult, nil
 }
 
-func (c Client) ReplaceBackend(name string, backend Backend, transactionId string) (*Backend, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s?transaction_id=%s",
-		c.BaseUrl,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) ReplaceBackend(ctx context.Context, name string, backend Backend) (*Backend, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("backends/%s", name))
 
 	body, err := json.Marshal(backend)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.executeApiReturnsBackend(apiUrl, "PUT", bytes.NewReader(body))
+	return cfg.executeApiReturnsBackend(ctx, apiUrl, "PUT", bytes.NewReader(body))
 }
 
-func (c Client) DeleteBackend(name string, transactionId string) error {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s?transaction_id=%s",
-		c.BaseUrl,
-		name,
-		transactionId,
-	)
-	_, err := c.callApi(apiUrl, "DELETE", nil)
+func (cfg *Configuration) DeleteBackend(ctx context.Context, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("backends/%s", name))
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
 
 	return err
 }
 
-func (c Client) executeApiReturnsBackend(apiUrl string, method string, body io.Reader) (*Backend, error) {
-	resTxt, err := c.callApi(apiUrl, method, body)
+func (cfg *Configuration) executeApiReturnsBackend(ctx context.Context, apiUrl string, method string, body io.Reader) (*Backend, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
 	if err != nil {
 		return nil, err
 	}
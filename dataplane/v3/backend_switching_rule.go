@@ -0,0 +1,126 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BackendSwitchingRule is a use_backend line of a frontend, addressed by its index within the frontend.
+// Name is the backend to use when the condition matches.
+type BackendSwitchingRule struct {
+	Name     *string `json:"name,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r BackendSwitchingRule) MarshalJSON() ([]byte, error) {
+	type plain BackendSwitchingRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *BackendSwitchingRule) UnmarshalJSON(data []byte) error {
+	type plain BackendSwitchingRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddBackendSwitchingRule inserts the rule at index; rules are evaluated in order and the first match wins
+func (cfg *Configuration) AddBackendSwitchingRule(ctx context.Context, frontend string, index int, rule BackendSwitchingRule) (*BackendSwitchingRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/backend_switching_rules/%d", parentPath(PARENT_TYPE_FRONTEND, frontend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsBackendSwitchingRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetBackendSwitchingRule(ctx context.Context, frontend string, index int) (*BackendSwitchingRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/backend_switching_rules/%d", parentPath(PARENT_TYPE_FRONTEND, frontend), index))
+
+	return cfg.executeApiReturnsBackendSwitchingRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListBackendSwitchingRules(ctx context.Context, frontend string) ([]BackendSwitchingRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/backend_switching_rules", parentPath(PARENT_TYPE_FRONTEND, frontend)))
+
+	return cfg.executeApiReturnsBackendSwitchingRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceBackendSwitchingRule(ctx context.Context, frontend string, index int, rule BackendSwitchingRule) (*BackendSwitchingRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/backend_switching_rules/%d", parentPath(PARENT_TYPE_FRONTEND, frontend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsBackendSwitchingRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllBackendSwitchingRules replaces every use_backend rule of the frontend with rules, in order
+func (cfg *Configuration) ReplaceAllBackendSwitchingRules(ctx context.Context, frontend string, rules []BackendSwitchingRule) ([]BackendSwitchingRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/backend_switching_rules", parentPath(PARENT_TYPE_FRONTEND, frontend)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsBackendSwitchingRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteBackendSwitchingRule(ctx context.Context, frontend string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/backend_switching_rules/%d", parentPath(PARENT_TYPE_FRONTEND, frontend), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsBackendSwitchingRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*BackendSwitchingRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult BackendSwitchingRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsBackendSwitchingRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]BackendSwitchingRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []BackendSwitchingRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
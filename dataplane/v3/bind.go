@@ -2,57 +2,157 @@ package v3
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 )
 
+const (
+	BIND_VERIFY_NONE     = "none"
+	BIND_VERIFY_OPTIONAL = "optional"
+	BIND_VERIFY_REQUIRED = "required"
+)
+
+const (
+	BIND_LEVEL_USER     = "user"
+	BIND_LEVEL_OPERATOR = "operator"
+	BIND_LEVEL_ADMIN    = "admin"
+)
+
+const (
+	SSL_VERSION_SSLV3  = "SSLv3"
+	SSL_VERSION_TLSV10 = "TLSv1.0"
+	SSL_VERSION_TLSV11 = "TLSv1.1"
+	SSL_VERSION_TLSV12 = "TLSv1.2"
+	SSL_VERSION_TLSV13 = "TLSv1.3"
+)
+
+// Address prefixes selecting a QUIC listener, e.g. Address: "quic4@0.0.0.0".
+// A QUIC bind also needs Ssl, an SslCertificate and Alpn "h3".
+const (
+	BIND_ADDRESS_PREFIX_QUIC4 = "quic4@"
+	BIND_ADDRESS_PREFIX_QUIC6 = "quic6@"
+)
+
+// Bind is a bind line of a frontend. Timeouts are in milliseconds.
 type Bind struct {
-	Id      *string `json:"id,omitempty"`
-	Name    *string `json:"name,omitempty"`
-	Address *string `json:"address,omitempty"`
-	Port    *int    `json:"port,omitempty"`
-	V4V6    *bool   `json:"v4v6,omitempty"`
-	V6Only  *bool   `json:"v6only,omitempty"`
+	Id           *string `json:"id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Address      *string `json:"address,omitempty"`
+	Port         *int    `json:"port,omitempty"`
+	PortRangeEnd *int    `json:"port-range-end,omitempty"`
+	V4V6         *bool   `json:"v4v6,omitempty"`
+	V6Only       *bool   `json:"v6only,omitempty"`
+
+	Ssl                  *bool   `json:"ssl,omitempty"`
+	SslCertificate       *string `json:"ssl_certificate,omitempty"`
+	CrtList              *string `json:"crt_list,omitempty"`
+	CaFile               *string `json:"ca_file,omitempty"`
+	CrlFile              *string `json:"crl_file,omitempty"`
+	Verify               *string `json:"verify,omitempty"`
+	Alpn                 *string `json:"alpn,omitempty"`
+	SslMinVer            *string `json:"ssl_min_ver,omitempty"`
+	SslMaxVer            *string `json:"ssl_max_ver,omitempty"`
+	Ciphers              *string `json:"ciphers,omitempty"`
+	Ciphersuites         *string `json:"ciphersuites,omitempty"`
+	Curves               *string `json:"curves,omitempty"`
+	StrictSni            *bool   `json:"strict_sni,omitempty"`
+	PreferClientCiphers  *bool   `json:"prefer_client_ciphers,omitempty"`
+	NoTlsTickets         *bool   `json:"no_tls_tickets,omitempty"`
+	Allow0rtt            *bool   `json:"allow_0rtt,omitempty"`
+	GenerateCertificates *bool   `json:"generate_certificates,omitempty"`
+	CaSignFile           *string `json:"ca_sign_file,omitempty"`
+
+	AcceptProxy    *bool   `json:"accept_proxy,omitempty"`
+	Transparent    *bool   `json:"transparent,omitempty"`
+	Interface      *string `json:"interface,omitempty"`
+	Namespace      *string `json:"namespace,omitempty"`
+	Maxconn        *int    `json:"maxconn,omitempty"`
+	Backlog        *string `json:"backlog,omitempty"`
+	Process        *string `json:"process,omitempty"`
+	Thread         *string `json:"thread,omitempty"`
+	TcpUserTimeout *int    `json:"tcp_user_timeout,omitempty"`
+	DeferAccept    *bool   `json:"defer_accept,omitempty"`
+	Mss            *string `json:"mss,omitempty"`
+	Tfo            *bool   `json:"tfo,omitempty"`
+	Nice           *int    `json:"nice,omitempty"`
+	Proto          *string `json:"proto,omitempty"`
+
+	QuicCcAlgo     *string `json:"quic-cc-algo,omitempty"`
+	QuicForceRetry *bool   `json:"quic-force-retry,omitempty"`
+	QuicSocket     *string `json:"quic-socket,omitempty"`
+
+	// socket options for stats and runtime API listeners
+	Level             *string `json:"level,omitempty"`
+	SeverityOutput    *string `json:"severity_output,omitempty"`
+	ExposeFdListeners *bool   `json:"expose_fd_listeners,omitempty"`
+	Mode              *string `json:"mode,omitempty"`
+	User              *string `json:"user,omitempty"`
+	Group             *string `json:"group,omitempty"`
+	Uid               *string `json:"uid,omitempty"`
+	Gid               *int    `json:"gid,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b Bind) MarshalJSON() ([]byte, error) {
+	type plain Bind
+	return marshalWithExtra(plain(b), b.Extra)
 }
 
-func (c Client) AddBind(frontend string, transactionId string, bind Bind) (*Bind, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s/binds?transaction_id=%s",
-		c.BaseUrl,
-		frontend,
-		transactionId,
-	)
+func (b *Bind) UnmarshalJSON(data []byte) error {
+	type plain Bind
+	extra, err := unmarshalWithExtra(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+
+	b.Extra = extra
+	return nil
+}
+
+func (c Client) AddBind(ctx context.Context, frontend string, transactionId string, bind Bind) (*Bind, error) {
+	return c.inTransaction(transactionId).AddBind(ctx, frontend, bind)
+}
+
+func (c Client) GetBind(ctx context.Context, name string, frontend string, transactionId string) (*Bind, error) {
+	return c.inTransaction(transactionId).GetBind(ctx, frontend, name)
+}
+
+func (c Client) ListBinds(ctx context.Context, frontend string, transactionId string) ([]Bind, error) {
+	return c.inTransaction(transactionId).ListBinds(ctx, frontend)
+}
+
+func (c Client) ReplaceBind(ctx context.Context, frontend string, transactionId string, bind Bind) (*Bind, error) {
+	return c.inTransaction(transactionId).ReplaceBind(ctx, frontend, bind)
+}
+
+func (c Client) DeleteBind(ctx context.Context, name string, frontend string, transactionId string) error {
+	return c.inTransaction(transactionId).DeleteBind(ctx, frontend, name)
+}
+
+func (cfg *Configuration) AddBind(ctx context.Context, frontend string, bind Bind) (*Bind, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("frontends/%s/binds", frontend))
 
 	reqTxt, err := json.Marshal(bind)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
 	}
 
-	return c.executeApiReturnsBind(apiUrl, "POST", bytes.NewReader(reqTxt))
+	return cfg.executeApiReturnsBind(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
 }
 
-func (c Client) GetBind(name string, frontend string, transactionId string) (*Bind, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s/binds/%s?transaction_id=%s",
-		c.BaseUrl,
-		frontend,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) GetBind(ctx context.Context, frontend string, name string) (*Bind, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("frontends/%s/binds/%s", frontend, name))
 
-	return c.executeApiReturnsBind(apiUrl, "GET", nil)
+	return cfg.executeApiReturnsBind(ctx, apiUrl, "GET", nil)
 }
 
-func (c Client) ListBinds(frontend string, transactionId string) ([]Bind, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s/binds?transaction_id=%s",
-		c.BaseUrl,
-		frontend,
-		transactionId,
-	)
+func (cfg *Configuration) ListBinds(ctx context.Context, frontend string) ([]Bind, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("frontends/%s/binds", frontend))
 
-	resTxt, err := c.callApi(apiUrl, "GET", nil)
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -69,39 +169,31 @@ func (c Client) ListBinds(frontend string, transactionId string) ([]Bind, error)
 	return resResult, nil
 }
 
-func (c Client) ReplaceBind(frontend string, transactionId string, bind Bind) (*Bind, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s/binds/%s?transaction_id=%s",
-		c.BaseUrl,
-		frontend,
-		*bind.Name,
-		transactionId,
-	)
+func (cfg *Configuration) ReplaceBind(ctx context.Context, frontend string, bind Bind) (*Bind, error) {
+	if bind.Name == nil {
+		return nil, fmt.Errorf("bind name is required")
+	}
+
+	apiUrl := cfg.writeUrl(fmt.Sprintf("frontends/%s/binds/%s", frontend, *bind.Name))
 
 	reqTxt, err := json.Marshal(bind)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
 	}
 
-	return c.executeApiReturnsBind(apiUrl, "PUT", bytes.NewReader(reqTxt))
+	return cfg.executeApiReturnsBind(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
 }
 
-func (c Client) DeleteBind(name string, frontend string, transactionId string) error {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s/binds/%s?transaction_id=%s",
-		c.BaseUrl,
-		frontend,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) DeleteBind(ctx context.Context, frontend string, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("frontends/%s/binds/%s", frontend, name))
 
-	_, err := c.callApi(apiUrl, "DELETE", nil)
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
 
 	return err
 }
 
-func (c Client) executeApiReturnsBind(apiUrl string, method string, body io.Reader) (*Bind, error) {
-	resTxt, err := c.callApi(apiUrl, method, body)
+func (cfg *Configuration) executeApiReturnsBind(ctx context.Context, apiUrl string, method string, body io.Reader) (*Bind, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1,14 +1,23 @@
 package v3
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type Client struct {
 	Credential string
 	BaseUrl    string
+
+	httpClient          *http.Client
+	userAgent           string
+	transactionAttempts int
+	reloadPollInterval  time.Duration
 }
 
 type NormalResponse struct {
@@ -23,44 +32,112 @@ func (c Client) constructAuthorizationHeader() http.Header {
 	return h
 }
 
-func (c Client) callApi(apiUrl string, method string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(method, apiUrl, body)
+func (c Client) constructHeader() http.Header {
+	h := c.constructAuthorizationHeader()
+	h.Add("Content-Type", "application/json")
+	if c.userAgent != "" {
+		h.Add("User-Agent", c.userAgent)
+	}
+
+	return h
+}
+
+// getHttpClient returns the HTTP client configured by NewClient, falling back to http.DefaultClient
+// for a Client built as a struct literal.
+func (c Client) getHttpClient() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+
+	return http.DefaultClient
+}
+
+func (c Client) callApi(ctx context.Context, apiUrl string, method string, body io.Reader) ([]byte, error) {
+	resTxt, _, err := c.callApiWithHeader(ctx, apiUrl, method, body)
+
+	return resTxt, err
+}
+
+// callApiWithHeader works like callApi and also returns the response headers, e.g. to read the Reload-ID
+func (c Client) callApiWithHeader(ctx context.Context, apiUrl string, method string, body io.Reader) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, apiUrl, body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	req.Header = c.constructAuthorizationHeader()
-	req.Header.Add("Content-Type", "application/json")
+	req.Header = c.constructHeader()
 
-	client := new(http.Client)
+	client := c.getHttpClient()
 	res, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		if ctxErr := contextError(ctx, err); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
 	resTxt, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("undefined response")
+		if ctxErr := contextError(ctx, err); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, fmt.Errorf("undefined response")
 	}
 
-	switch res.StatusCode {
-	case http.StatusUnauthorized:
-		return resTxt, &UnauthorizedError{Message: string(resTxt)}
+	if res.StatusCode/100 != 2 { // 2xx status codes are successful
+		return resTxt, res.Header, newResponseError(req, res.StatusCode, resTxt)
+	}
+
+	return resTxt, res.Header, nil
+}
+
+// newResponseError decodes the error payload of a failed request into the error type matching its status code
+func newResponseError(req *http.Request, statusCode int, body []byte) error {
+	apiErr := APIError{
+		StatusCode: statusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Body:       body,
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	var payload NormalResponse
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Code = payload.Code
+		if payload.Message != nil {
+			apiErr.Message = *payload.Message
+		}
+	}
+
+	switch statusCode {
 	case http.StatusBadRequest:
-		return resTxt, &BadRequestError{Message: string(resTxt)}
+		return &BadRequestError{APIError: apiErr}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{APIError: apiErr}
 	case http.StatusNotFound:
-		return resTxt, &NotFoundError{Message: string(resTxt)}
+		return &NotFoundError{APIError: apiErr}
+	case http.StatusNotAcceptable:
+		return &NotAcceptableError{APIError: apiErr}
 	case http.StatusConflict:
-		return resTxt, &ConflictError{Message: string(resTxt)}
+		return &ConflictError{APIError: apiErr}
+	case http.StatusUnprocessableEntity:
+		return &UnprocessableEntityError{APIError: apiErr}
+	case http.StatusInternalServerError:
+		return &InternalServerError{APIError: apiErr}
+	case http.StatusServiceUnavailable:
+		return &ServiceUnavailableError{APIError: apiErr}
 	default:
-		if res.StatusCode/100 != 2 { // 2xx status codes are successful
-			return resTxt, &UnknownError{
-				Message:    string(resTxt),
-				StatusCode: res.StatusCode,
-			}
-		}
+		return &UnknownError{APIError: apiErr}
+	}
+}
+
+// contextError returns a CanceledError wrapping err when ctx has been canceled or its deadline has passed,
+// and nil otherwise.
+func contextError(ctx context.Context, err error) error {
+	if ctx.Err() == nil {
+		return nil
 	}
 
-	return resTxt, nil
+	return &CanceledError{Message: err.Error(), Err: ctx.Err()}
 }
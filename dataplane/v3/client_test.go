@@ -0,0 +1,46 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCanceledRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  func() (context.Context, context.CancelFunc)
+		want error
+	}{
+		{"deadline", func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 50*time.Millisecond)
+		}, context.DeadlineExceeded},
+		{"cancel", func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			return ctx, cancel
+		}, context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// hang until the client gives up
+				<-r.Context().Done()
+			}))
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			_, err := client.GetVersion(ctx)
+			if !IsCanceled(err) {
+				t.Fatalf("err = %#v, want a CanceledError", err)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want it to wrap %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// Values of on/off options in configuration models, e.g. Backend.Abortonclose
+const (
+	OPTION_ENABLED  = "enabled"
+	OPTION_DISABLED = "disabled"
+)
+
+// Types of the sections that own child resources such as ACLs
+const (
+	PARENT_TYPE_BACKEND  = "backend"
+	PARENT_TYPE_DEFAULTS = "defaults"
+	PARENT_TYPE_FRONTEND = "frontend"
+)
+
+// Values of the Cond field of rules, applying the rule if or unless CondTest matches
+const (
+	COND_IF     = "if"
+	COND_UNLESS = "unless"
+)
+
+// CONFIGURATION_VERSION_HEADER is the response header carrying the configuration version after a change
+const CONFIGURATION_VERSION_HEADER = "Configuration-Version"
+
+// Configuration binds configuration operations to a transaction, to a configuration version, or to the live configuration.
+// Within a transaction, changes are staged until the transaction is committed.
+// At a version, every change is applied on its own and is rejected once the configuration has moved past that version.
+// The live configuration returned by Current can only be read.
+type Configuration struct {
+	client        Client
+	transactionId string
+	version       int
+	forceReload   bool
+	readOnly      bool
+
+	mu        sync.Mutex
+	reloadIds []string
+}
+
+// Applied describes the changes made by ApplyAtVersion
+type Applied struct {
+	// Version is the configuration version after the last successful change
+	Version int
+	// ReloadIds identify the HAProxy reloads triggered by the changes, in the order the changes completed (see WaitForReload).
+	// Changes that HAProxy applies without a reload have none.
+	ReloadIds []string
+}
+
+// ApplyAtVersion runs fn with a Configuration that applies each change directly, without a transaction,
+// starting at the given configuration version. Every successful change moves the Configuration to the next version,
+// so fn can make several changes in a row; the Configuration must not be used once fn has returned.
+// Changes are not atomic: those made before a failing one stay applied, and Applied describes them even when an error is returned.
+// Use WithTransaction to apply several changes all at once.
+// With forceReload, HAProxy reloads right after each change instead of at the next reload interval.
+func (c Client) ApplyAtVersion(ctx context.Context, version int, forceReload bool, fn func(ctx context.Context, cfg *Configuration) error) (*Applied, error) {
+	cfg := &Configuration{client: c, version: version, forceReload: forceReload}
+	err := fn(ctx, cfg)
+
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+
+	return &Applied{Version: cfg.version, ReloadIds: cfg.reloadIds}, err
+}
+
+// Current returns a Configuration reading the live configuration, outside any transaction and without changing its version.
+// Changes made through it are rejected with an InternalError before reaching the API; use WithTransaction or ApplyAtVersion instead.
+func (c Client) Current() *Configuration {
+	return &Configuration{client: c, readOnly: true}
+}
+
+func (c Client) inTransaction(transactionId string) *Configuration {
+	return &Configuration{client: c, transactionId: transactionId}
+}
+
+// readUrl returns the URL of a configuration endpoint queried for reading
+func (cfg *Configuration) readUrl(path string) string {
+	query := url.Values{}
+	if cfg.transactionId != "" {
+		query.Set("transaction_id", cfg.transactionId)
+	}
+
+	return cfg.configurationUrl(path, query)
+}
+
+// writeUrl returns the URL of a configuration endpoint queried for a change
+func (cfg *Configuration) writeUrl(path string) string {
+	query := url.Values{}
+	if cfg.transactionId != "" {
+		query.Set("transaction_id", cfg.transactionId)
+	} else {
+		cfg.mu.Lock()
+		query.Set("version", strconv.Itoa(cfg.version))
+		cfg.mu.Unlock()
+		if cfg.forceReload {
+			query.Set("force_reload", "true")
+		}
+	}
+
+	return cfg.configurationUrl(path, query)
+}
+
+// parentPath returns the configuration path of the section owning a child resource
+func parentPath(parentType string, parentName string) string {
+	if parentType == PARENT_TYPE_DEFAULTS {
+		return fmt.Sprintf("defaults/%s", parentName)
+	}
+
+	return fmt.Sprintf("%ss/%s", parentType, parentName)
+}
+
+func (cfg *Configuration) configurationUrl(path string, query url.Values) string {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/%s", cfg.client.BaseUrl, path)
+	if len(query) == 0 {
+		return apiUrl
+	}
+
+	return fmt.Sprintf("%s?%s", apiUrl, query.Encode())
+}
+
+func (cfg *Configuration) callApi(ctx context.Context, apiUrl string, method string, body io.Reader) ([]byte, error) {
+	if cfg.readOnly && method != "GET" {
+		return nil, &InternalError{Message: fmt.Sprintf("%s %s: the current configuration is read only, use WithTransaction or ApplyAtVersion to change it", method, apiUrl)}
+	}
+
+	resTxt, header, err := cfg.client.callApiWithHeader(ctx, apiUrl, method, body)
+	if err != nil {
+		return resTxt, err
+	}
+
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+
+	// changes in a transaction reload when it is committed, see Transaction.ReloadId
+	if cfg.transactionId == "" && method != "GET" {
+		cfg.version = nextVersion(cfg.version, header)
+		if reloadId := header.Get(RELOAD_ID_HEADER); reloadId != "" {
+			cfg.reloadIds = append(cfg.reloadIds, reloadId)
+		}
+	}
+
+	return resTxt, nil
+}
+
+// nextVersion returns the configuration version after a change made at version,
+// as reported by the response header or else as the following version
+func nextVersion(version int, header http.Header) int {
+	if reported, err := strconv.Atoi(header.Get(CONFIGURATION_VERSION_HEADER)); err == nil && reported > version {
+		return reported
+	}
+
+	return version + 1
+}
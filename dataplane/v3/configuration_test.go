@@ -0,0 +1,215 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestApplyAtVersionMovesToNextVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		reportedStep  int
+		wantVersions  []string
+		wantFinal     int
+		forceReload   bool
+		wantReloadArg string
+		wantReloadIds []string
+	}{
+		{"without version header", 0, []string{"7", "8"}, 9, false, "", nil},
+		{"with version header", 5, []string{"7", "12"}, 17, true, "true", []string{"reload-7", "reload-12"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var versions []string
+			client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				version := r.URL.Query().Get("version")
+				versions = append(versions, version)
+				if got := r.URL.Query().Get("force_reload"); got != tt.wantReloadArg {
+					t.Errorf("force_reload = %q, want %q", got, tt.wantReloadArg)
+				}
+				if tt.forceReload {
+					w.Header().Set(RELOAD_ID_HEADER, "reload-"+version)
+				}
+				if tt.reportedStep != 0 {
+					current, _ := strconv.Atoi(version)
+					w.Header().Set(CONFIGURATION_VERSION_HEADER, strconv.Itoa(current+tt.reportedStep))
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"name":"web"}`)
+			}))
+
+			applied, err := client.ApplyAtVersion(context.Background(), 7, tt.forceReload, func(ctx context.Context, cfg *Configuration) error {
+				name := "web"
+				if _, err := cfg.AddBackend(ctx, Backend{Name: &name}); err != nil {
+					return err
+				}
+				_, err := cfg.AddFrontend(ctx, Frontend{Name: &name})
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if fmt.Sprint(versions) != fmt.Sprint(tt.wantVersions) {
+				t.Errorf("versions = %v, want %v", versions, tt.wantVersions)
+			}
+			if applied.Version != tt.wantFinal {
+				t.Errorf("Applied.Version = %d, want %d", applied.Version, tt.wantFinal)
+			}
+			if fmt.Sprint(applied.ReloadIds) != fmt.Sprint(tt.wantReloadIds) {
+				t.Errorf("Applied.ReloadIds = %v, want %v", applied.ReloadIds, tt.wantReloadIds)
+			}
+		})
+	}
+}
+
+func TestApplyAtVersionKeepsVersionOnFailure(t *testing.T) {
+	client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"code":409,"message":"version mismatch"}`)
+	}))
+
+	applied, err := client.ApplyAtVersion(context.Background(), 7, false, func(ctx context.Context, cfg *Configuration) error {
+		return cfg.DeleteBackend(ctx, "web")
+	})
+	if !IsConflict(err) {
+		t.Fatalf("err = %v, want a conflict", err)
+	}
+	if applied.Version != 7 || len(applied.ReloadIds) != 0 {
+		t.Errorf("Applied = %+v, want version 7 and no reloads", applied)
+	}
+}
+
+func TestCurrentReadsLiveConfiguration(t *testing.T) {
+	var requests []string
+	client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.String())
+		fmt.Fprint(w, `{"daemon":true}`)
+	}))
+
+	global, err := client.Current().GetGlobal(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if global.Daemon == nil || !*global.Daemon {
+		t.Errorf("Global = %+v, want daemon", global)
+	}
+
+	var internalErr *InternalError
+	if _, err := client.Current().ReplaceGlobal(context.Background(), *global); !errors.As(err, &internalErr) {
+		t.Errorf("ReplaceGlobal err = %v, want an InternalError", err)
+	}
+
+	if fmt.Sprint(requests) != "[GET /v3/services/haproxy/configuration/global]" {
+		t.Errorf("requests = %v, want only the live read", requests)
+	}
+}
+
+func TestCurrentListsLiveResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     func(ctx context.Context, cfg *Configuration) error
+		wantPath string
+	}{
+		{"acls", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListAcls(ctx, PARENT_TYPE_FRONTEND, "web")
+			return err
+		}, "frontends/web/acls"},
+		{"backend switching rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListBackendSwitchingRules(ctx, "web")
+			return err
+		}, "frontends/web/backend_switching_rules"},
+		{"http request rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListHttpRequestRules(ctx, PARENT_TYPE_FRONTEND, "web")
+			return err
+		}, "frontends/web/http_request_rules"},
+		{"http response rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListHttpResponseRules(ctx, PARENT_TYPE_BACKEND, "web")
+			return err
+		}, "backends/web/http_response_rules"},
+		{"http after response rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListHttpAfterResponseRules(ctx, PARENT_TYPE_DEFAULTS, "unnamed_defaults_1")
+			return err
+		}, "defaults/unnamed_defaults_1/http_after_response_rules"},
+		{"tcp request rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListTcpRequestRules(ctx, PARENT_TYPE_FRONTEND, "web")
+			return err
+		}, "frontends/web/tcp_request_rules"},
+		{"tcp response rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListTcpResponseRules(ctx, "web")
+			return err
+		}, "backends/web/tcp_response_rules"},
+		{"tcp checks", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListTcpChecks(ctx, PARENT_TYPE_BACKEND, "web")
+			return err
+		}, "backends/web/tcp_checks"},
+		{"http checks", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListHttpChecks(ctx, PARENT_TYPE_BACKEND, "web")
+			return err
+		}, "backends/web/http_checks"},
+		{"server switching rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListServerSwitchingRules(ctx, "web")
+			return err
+		}, "backends/web/server_switching_rules"},
+		{"stick rules", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListStickRules(ctx, "web")
+			return err
+		}, "backends/web/stick_rules"},
+		{"defaults", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListDefaults(ctx)
+			return err
+		}, "defaults"},
+		{"resolvers", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListResolvers(ctx)
+			return err
+		}, "resolvers"},
+		{"nameservers", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListNameservers(ctx, "dns")
+			return err
+		}, "resolvers/dns/nameservers"},
+		{"peer sections", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListPeerSections(ctx)
+			return err
+		}, "peer_section"},
+		{"peer entries", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListPeerEntries(ctx, "mypeers")
+			return err
+		}, "peers/mypeers/peer_entries"},
+		{"peer binds", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListPeerBinds(ctx, "mypeers")
+			return err
+		}, "peers/mypeers/binds"},
+		{"peer servers", func(ctx context.Context, cfg *Configuration) error {
+			_, err := cfg.ListPeerServers(ctx, "mypeers")
+			return err
+		}, "peers/mypeers/servers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested string
+			client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = r.Method + " " + r.URL.String()
+				fmt.Fprint(w, `[]`)
+			}))
+
+			if err := tt.list(context.Background(), client.Current()); err != nil {
+				t.Fatal(err)
+			}
+
+			if want := "GET /v3/services/haproxy/configuration/" + tt.wantPath; requested != want {
+				t.Errorf("requested %q, want %q", requested, want)
+			}
+		})
+	}
+}
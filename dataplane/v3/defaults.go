@@ -0,0 +1,163 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Defaults is a named defaults section. Frontends and backends inherit it through their From field.
+// Timeouts are in milliseconds; fields left nil are removed from the section by ReplaceDefaults.
+type Defaults struct {
+	Name        *string         `json:"name,omitempty"`
+	From        *string         `json:"from,omitempty"`
+	Mode        *string         `json:"mode,omitempty"`
+	Balance     *BackendBalance `json:"balance,omitempty"`
+	Maxconn     *int            `json:"maxconn,omitempty"`
+	Backlog     *int            `json:"backlog,omitempty"`
+	Description *string         `json:"description,omitempty"`
+
+	CheckTimeout         *int `json:"check_timeout,omitempty"`
+	ClientTimeout        *int `json:"client_timeout,omitempty"`
+	ClientFinTimeout     *int `json:"client_fin_timeout,omitempty"`
+	ConnectTimeout       *int `json:"connect_timeout,omitempty"`
+	HttpKeepAliveTimeout *int `json:"http_keep_alive_timeout,omitempty"`
+	HttpRequestTimeout   *int `json:"http_request_timeout,omitempty"`
+	QueueTimeout         *int `json:"queue_timeout,omitempty"`
+	ServerTimeout        *int `json:"server_timeout,omitempty"`
+	ServerFinTimeout     *int `json:"server_fin_timeout,omitempty"`
+	TarpitTimeout        *int `json:"tarpit_timeout,omitempty"`
+	TunnelTimeout        *int `json:"tunnel_timeout,omitempty"`
+
+	AdvCheck        *string               `json:"adv_check,omitempty"`
+	HttpchkParams   *BackendHttpchkParams `json:"httpchk_params,omitempty"`
+	Checkcache      *string               `json:"checkcache,omitempty"`
+	LogHealthChecks *string               `json:"log_health_checks,omitempty"`
+	DefaultServer   *DefaultServer        `json:"default_server,omitempty"`
+
+	Abortonclose       *string      `json:"abortonclose,omitempty"`
+	Forwardfor         *Forwardfor  `json:"forwardfor,omitempty"`
+	Compression        *Compression `json:"compression,omitempty"`
+	HttpBufferRequest  *string      `json:"http-buffer-request,omitempty"`
+	HttpConnectionMode *string      `json:"http_connection_mode,omitempty"`
+	HttpReuse          *string      `json:"http_reuse,omitempty"`
+	Clitcpka           *string      `json:"clitcpka,omitempty"`
+	Srvtcpka           *string      `json:"srvtcpka,omitempty"`
+	Tcpka              *string      `json:"tcpka,omitempty"`
+	Nolinger           *string      `json:"nolinger,omitempty"`
+
+	Cookie     *BackendCookie     `json:"cookie,omitempty"`
+	HashType   *BackendHashType   `json:"hash_type,omitempty"`
+	Redispatch *BackendRedispatch `json:"redispatch,omitempty"`
+	Retries    *int               `json:"retries,omitempty"`
+	RetryOn    *string            `json:"retry_on,omitempty"`
+
+	Httplog           *bool   `json:"httplog,omitempty"`
+	Httpslog          *string `json:"httpslog,omitempty"`
+	Tcplog            *bool   `json:"tcplog,omitempty"`
+	Clflog            *bool   `json:"clflog,omitempty"`
+	LogFormat         *string `json:"log_format,omitempty"`
+	LogFormatSd       *string `json:"log_format_sd,omitempty"`
+	ErrorLogFormat    *string `json:"error_log_format,omitempty"`
+	LogTag            *string `json:"log_tag,omitempty"`
+	LogSeparateErrors *string `json:"log_separate_errors,omitempty"`
+	Logasap           *string `json:"logasap,omitempty"`
+	Dontlognull       *string `json:"dontlognull,omitempty"`
+	DontlogNormal     *string `json:"dontlog_normal,omitempty"`
+	UniqueIdFormat    *string `json:"unique_id_format,omitempty"`
+	UniqueIdHeader    *string `json:"unique_id_header,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (d Defaults) MarshalJSON() ([]byte, error) {
+	type plain Defaults
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+func (d *Defaults) UnmarshalJSON(data []byte) error {
+	type plain Defaults
+	extra, err := unmarshalWithExtra(data, (*plain)(d))
+	if err != nil {
+		return err
+	}
+
+	d.Extra = extra
+	return nil
+}
+
+func (cfg *Configuration) AddDefaults(ctx context.Context, defaults Defaults) (*Defaults, error) {
+	apiUrl := cfg.writeUrl("defaults")
+
+	body, err := json.Marshal(defaults)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsDefaults(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetDefaults(ctx context.Context, name string) (*Defaults, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("defaults/%s", name))
+
+	return cfg.executeApiReturnsDefaults(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListDefaults(ctx context.Context) ([]Defaults, error) {
+	apiUrl := cfg.readUrl("defaults")
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Defaults
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) ReplaceDefaults(ctx context.Context, name string, defaults Defaults) (*Defaults, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("defaults/%s", name))
+
+	body, err := json.Marshal(defaults)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsDefaults(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) DeleteDefaults(ctx context.Context, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("defaults/%s", name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsDefaults(ctx context.Context, apiUrl string, method string, body io.Reader) (*Defaults, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult Defaults
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
@@ -0,0 +1,9 @@
+// Package v3 is a client for version 3 of the HAProxy Data Plane API.
+//
+// Configuration models declare the properties this library knows about, and also carry an Extra field holding
+// every other property of the API payload, e.g. one added by a newer Data Plane API.
+// Extra is filled when a model is read and sent back unchanged when it is written,
+// so a Get → Replace cycle never deletes configuration that this library does not model.
+// A property declared by the model always comes from its field, even when the field is unset,
+// so a stale copy in Extra cannot override it. Nested objects such as Backend.Balance keep their own Extra.
+package v3
@@ -5,9 +5,29 @@ import (
 	"fmt"
 )
 
+// APIError holds the details of a non-2xx Data Plane API response.
+// Every error type returned for an API failure embeds it.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Code is the code reported in the response payload, if any
+	Code int
+	// Message is the message reported in the response payload, or the raw body when it is not JSON
+	Message string
+	// Method and URL identify the request that failed
+	Method string
+	URL    string
+	// Body is the raw response body
+	Body []byte
+}
+
+func (e *APIError) apiError() *APIError {
+	return e
+}
+
 // NotFoundError represents a 404 Not Found error
 type NotFoundError struct {
-	Message string
+	APIError
 }
 
 func (e *NotFoundError) Error() string {
@@ -16,7 +36,7 @@ func (e *NotFoundError) Error() string {
 
 // BadRequestError represents a 400 Bad Request error
 type BadRequestError struct {
-	Message string
+	APIError
 }
 
 func (e *BadRequestError) Error() string {
@@ -34,7 +54,7 @@ func (e *InvalidResponseError) Error() string {
 
 // UnauthorizedError represents a 401 Unauthorized error (Auth Failed)
 type UnauthorizedError struct {
-	Message string
+	APIError
 }
 
 func (e *UnauthorizedError) Error() string {
@@ -43,7 +63,7 @@ func (e *UnauthorizedError) Error() string {
 
 // ConflictError represents a 409 Conflict error
 type ConflictError struct {
-	Message string
+	APIError
 }
 
 func (e *ConflictError) Error() string {
@@ -54,16 +74,67 @@ func (e *ConflictError) Error() string {
 type CommitFailedError struct {
 	Message       string
 	TransactionID string
+	// Err is the API error that rejected the commit, if any
+	Err error
 }
 
 func (e *CommitFailedError) Error() string {
 	return fmt.Sprintf("commit failed for transaction %s: %s", e.TransactionID, e.Message)
 }
 
+func (e *CommitFailedError) Unwrap() error {
+	return e.Err
+}
+
+// NotAcceptableError represents a 406 Not Acceptable error, e.g. an outdated transaction
+type NotAcceptableError struct {
+	APIError
+}
+
+func (e *NotAcceptableError) Error() string {
+	return fmt.Sprintf("not acceptable: %s", e.Message)
+}
+
+// UnprocessableEntityError represents a 422 Unprocessable Entity error, e.g. a configuration validation failure
+type UnprocessableEntityError struct {
+	APIError
+}
+
+func (e *UnprocessableEntityError) Error() string {
+	return fmt.Sprintf("unprocessable entity: %s", e.Message)
+}
+
+// InternalServerError represents a 500 Internal Server Error
+type InternalServerError struct {
+	APIError
+}
+
+func (e *InternalServerError) Error() string {
+	return fmt.Sprintf("internal server error: %s", e.Message)
+}
+
+// ServiceUnavailableError represents a 503 Service Unavailable error
+type ServiceUnavailableError struct {
+	APIError
+}
+
+func (e *ServiceUnavailableError) Error() string {
+	return fmt.Sprintf("service unavailable: %s", e.Message)
+}
+
+// ReloadFailedError represents a HAProxy reload that finished with the failed status
+type ReloadFailedError struct {
+	Message  string
+	ReloadID string
+}
+
+func (e *ReloadFailedError) Error() string {
+	return fmt.Sprintf("reload %s failed: %s", e.ReloadID, e.Message)
+}
+
 // UnknownError represents any other non-2xx status code with HTTP status information
 type UnknownError struct {
-	Message    string
-	StatusCode int
+	APIError
 }
 
 func (e *UnknownError) Error() string {
@@ -79,6 +150,21 @@ func (e *InternalError) Error() string {
 	return fmt.Sprintf("internal error: %s", e.Message)
 }
 
+// CanceledError represents a request aborted because its context was canceled or its deadline passed
+type CanceledError struct {
+	Message string
+	Err     error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("canceled: %s", e.Message)
+}
+
+// Unwrap returns the context error, so errors.Is(err, context.DeadlineExceeded) works as expected
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
 // Helper functions to check error types
 func IsNotFound(err error) bool {
 	var notFoundErr *NotFoundError
@@ -100,6 +186,22 @@ func IsCommitFailed(err error) bool {
 	return errors.As(err, &commitFailedErr)
 }
 
+// IsVersionConflict reports whether err is a commit rejected because the configuration version changed
+// after the transaction was created (406 Not Acceptable or 409 Conflict)
+func IsVersionConflict(err error) bool {
+	var commitFailedErr *CommitFailedError
+	if !errors.As(err, &commitFailedErr) {
+		return false
+	}
+
+	return IsNotAcceptable(commitFailedErr.Err) || IsConflict(commitFailedErr.Err)
+}
+
+func IsReloadFailed(err error) bool {
+	var reloadFailedErr *ReloadFailedError
+	return errors.As(err, &reloadFailedErr)
+}
+
 func IsBadRequest(err error) bool {
 	var badRequestErr *BadRequestError
 	return errors.As(err, &badRequestErr)
@@ -110,11 +212,44 @@ func IsUnknownError(err error) bool {
 	return errors.As(err, &unknownErr)
 }
 
-// GetHTTPStatusCode returns the HTTP status code from an UnknownError, or 0 if not applicable
+func IsNotAcceptable(err error) bool {
+	var notAcceptableErr *NotAcceptableError
+	return errors.As(err, &notAcceptableErr)
+}
+
+func IsUnprocessableEntity(err error) bool {
+	var unprocessableErr *UnprocessableEntityError
+	return errors.As(err, &unprocessableErr)
+}
+
+func IsInternalServerError(err error) bool {
+	var internalServerErr *InternalServerError
+	return errors.As(err, &internalServerErr)
+}
+
+func IsServiceUnavailable(err error) bool {
+	var serviceUnavailableErr *ServiceUnavailableError
+	return errors.As(err, &serviceUnavailableErr)
+}
+
+func IsCanceled(err error) bool {
+	var canceledErr *CanceledError
+	return errors.As(err, &canceledErr)
+}
+
+// GetAPIError returns the response details carried by any API error in err's chain, or nil if there is none
+func GetAPIError(err error) *APIError {
+	var apiErr interface{ apiError() *APIError }
+	if errors.As(err, &apiErr) {
+		return apiErr.apiError()
+	}
+	return nil
+}
+
+// GetHTTPStatusCode returns the HTTP status code from an API error, or 0 if not applicable
 func GetHTTPStatusCode(err error) int {
-	var unknownErr *UnknownError
-	if errors.As(err, &unknownErr) {
-		return unknownErr.StatusCode
+	if apiErr := GetAPIError(err); apiErr != nil {
+		return apiErr.StatusCode
 	}
 	return 0
 }
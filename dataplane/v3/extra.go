@@ -0,0 +1,101 @@
+package v3
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// modelFields caches, per model type, the JSON property names the model declares
+var modelFields sync.Map
+
+// unmarshalWithExtra decodes data into v, which must be a pointer to a struct,
+// and returns the properties of data that the struct does not declare, for the model's Extra field (see the package doc).
+// A model's UnmarshalJSON calls it on a copy of its type without methods, to avoid recursing into itself.
+func unmarshalWithExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+
+	for name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(properties, name)
+	}
+	if len(properties) == 0 {
+		return nil, nil
+	}
+
+	return properties, nil
+}
+
+// marshalWithExtra encodes v and adds the extra properties that v does not set itself
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v))
+	for name, value := range extra {
+		// a property the model declares is owned by its field, even when the field is unset
+		if _, ok := known[name]; ok {
+			continue
+		}
+		properties[name] = value
+	}
+
+	return json.Marshal(properties)
+}
+
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	if names, ok := modelFields.Load(t); ok {
+		return names.(map[string]struct{})
+	}
+
+	names := map[string]struct{}{}
+	collectJsonFieldNames(t, names)
+	modelFields.Store(t, names)
+
+	return names
+}
+
+func collectJsonFieldNames(t reflect.Type, names map[string]struct{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectJsonFieldNames(embedded, names)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+}
@@ -0,0 +1,154 @@
+package v3
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func assertSameJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+}
+
+func TestExtraRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		model any
+		data  string
+	}{
+		{
+			name:  "top-level model",
+			model: &Frontend{},
+			data:  `{"name":"web","mode":"http","newthing":{"a":1}}`,
+		},
+		{
+			name:  "nested struct",
+			model: &Backend{},
+			data:  `{"name":"web","balance":{"algorithm":"roundrobin","newthing":1}}`,
+		},
+		{
+			name:  "nested struct in a slice",
+			model: &Backend{},
+			data:  `{"name":"web","cookie":{"name":"srv","attr":[{"value":"SameSite=Lax","newthing":true}]}}`,
+		},
+		{
+			name:  "embedded struct",
+			model: &Server{},
+			data:  `{"name":"web1","address":"10.0.0.1","check":"enabled","weight":10,"newthing":"x"}`,
+		},
+		{
+			name:  "embedded struct in a nested struct",
+			model: &Backend{},
+			data:  `{"name":"web","default_server":{"check":"enabled","newthing":"x"},"stick_table":{"type":"ip","newthing":2}}`,
+		},
+		{
+			name:  "global section",
+			model: &Global{},
+			data:  `{"runtime_apis":[{"address":"/var/run/haproxy.sock","level":"admin","ssl":true,"crt_list":"/etc/haproxy/crt.list","newthing":1}],"cpu_maps":[{"process":"1/all","cpu_set":"0-3","newthing":1}],"log_target_list":[{"address":"127.0.0.1","facility":"local0","newthing":1}],"tune_options":{"newthing":2},"newthing":3}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.data), tt.model); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := json.Marshal(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSameJSON(t, data, tt.data)
+		})
+	}
+}
+
+func TestExtraHoldsOnlyUnknownProperties(t *testing.T) {
+	var server Server
+	if err := json.Unmarshal([]byte(`{"name":"web1","check":"enabled","newthing":"x"}`), &server); err != nil {
+		t.Fatal(err)
+	}
+
+	// properties of the embedded ServerParams are known to Server
+	if len(server.Extra) != 1 || string(server.Extra["newthing"]) != `"x"` {
+		t.Errorf("Extra = %v, want only newthing", server.Extra)
+	}
+	if server.Check == nil || *server.Check != OPTION_ENABLED {
+		t.Errorf("Check = %v, want enabled", server.Check)
+	}
+
+	var backend Backend
+	if err := json.Unmarshal([]byte(`{"name":"web","balance":{"algorithm":"roundrobin"}}`), &backend); err != nil {
+		t.Fatal(err)
+	}
+	if backend.Extra != nil || backend.Balance.Extra != nil {
+		t.Errorf("Extra = %v and %v, want nil without unknown properties", backend.Extra, backend.Balance.Extra)
+	}
+}
+
+func TestDeclaredFieldsWinOverExtra(t *testing.T) {
+	name, check := "web1", OPTION_DISABLED
+	tests := []struct {
+		name  string
+		model any
+		want  string
+	}{
+		{
+			name: "set field",
+			model: Backend{
+				Name:  &name,
+				Extra: map[string]json.RawMessage{"name": json.RawMessage(`"stale"`), "newthing": json.RawMessage(`1`)},
+			},
+			want: `{"name":"web1","newthing":1}`,
+		},
+		{
+			name: "unset field",
+			model: Backend{
+				Name:  &name,
+				Extra: map[string]json.RawMessage{"mode": json.RawMessage(`"tcp"`)},
+			},
+			want: `{"name":"web1"}`,
+		},
+		{
+			name: "field of an embedded struct",
+			model: Server{
+				ServerParams: ServerParams{Check: &check},
+				Name:         &name,
+				Extra:        map[string]json.RawMessage{"check": json.RawMessage(`"enabled"`), "weight": json.RawMessage(`5`)},
+			},
+			want: `{"name":"web1","check":"disabled"}`,
+		},
+		{
+			name: "field of a nested struct",
+			model: Backend{
+				Name: &name,
+				Balance: &BackendBalance{
+					Algorithm: BACKEND_BALANCE_ALGORITHM_ROUNDROBIN,
+					Extra:     map[string]json.RawMessage{"algorithm": json.RawMessage(`"source"`)},
+				},
+			},
+			want: `{"name":"web1","balance":{"algorithm":"roundrobin"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSameJSON(t, data, tt.want)
+		})
+	}
+}
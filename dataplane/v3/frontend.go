@@ -2,15 +2,125 @@ package v3
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 )
 
 const (
-	FRONTEND_MODE_TCP = "tcp"
+	FRONTEND_MODE_HTTP = "http"
+	FRONTEND_MODE_TCP  = "tcp"
 )
 
+type Compression struct {
+	Algorithms []string `json:"algorithms,omitempty"`
+	Offload    *bool    `json:"offload,omitempty"`
+	Types      []string `json:"types,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (c Compression) MarshalJSON() ([]byte, error) {
+	type plain Compression
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *Compression) UnmarshalJSON(data []byte) error {
+	type plain Compression
+	extra, err := unmarshalWithExtra(data, (*plain)(c))
+	if err != nil {
+		return err
+	}
+
+	c.Extra = extra
+	return nil
+}
+
+type MonitorFail struct {
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (m MonitorFail) MarshalJSON() ([]byte, error) {
+	type plain MonitorFail
+	return marshalWithExtra(plain(m), m.Extra)
+}
+
+func (m *MonitorFail) UnmarshalJSON(data []byte) error {
+	type plain MonitorFail
+	extra, err := unmarshalWithExtra(data, (*plain)(m))
+	if err != nil {
+		return err
+	}
+
+	m.Extra = extra
+	return nil
+}
+
+type StatsAuth struct {
+	User   *string `json:"user,omitempty"`
+	Passwd *string `json:"passwd,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (s StatsAuth) MarshalJSON() ([]byte, error) {
+	type plain StatsAuth
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (s *StatsAuth) UnmarshalJSON(data []byte) error {
+	type plain StatsAuth
+	extra, err := unmarshalWithExtra(data, (*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	s.Extra = extra
+	return nil
+}
+
+// StatsOptions configures the statistics page served by a frontend
+type StatsOptions struct {
+	StatsEnable        *bool       `json:"stats_enable,omitempty"`
+	StatsUri           *string     `json:"stats_uri,omitempty"`
+	StatsRealm         *bool       `json:"stats_realm,omitempty"`
+	StatsRealmRealm    *string     `json:"stats_realm_realm,omitempty"`
+	StatsRefreshDelay  *int        `json:"stats_refresh_delay,omitempty"`
+	StatsAdmin         *bool       `json:"stats_admin,omitempty"`
+	StatsAdminCond     *string     `json:"stats_admin_cond,omitempty"`
+	StatsAdminCondTest *string     `json:"stats_admin_cond_test,omitempty"`
+	StatsAuths         []StatsAuth `json:"stats_auths,omitempty"`
+	StatsHideVersion   *bool       `json:"stats_hide_version,omitempty"`
+	StatsShowDesc      *string     `json:"stats_show_desc,omitempty"`
+	StatsShowLegends   *bool       `json:"stats_show_legends,omitempty"`
+	StatsShowNodeName  *string     `json:"stats_show_node_name,omitempty"`
+	StatsMaxconn       *int        `json:"stats_maxconn,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (s StatsOptions) MarshalJSON() ([]byte, error) {
+	type plain StatsOptions
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (s *StatsOptions) UnmarshalJSON(data []byte) error {
+	type plain StatsOptions
+	extra, err := unmarshalWithExtra(data, (*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	s.Extra = extra
+	return nil
+}
+
+// Frontend is a frontend section. Timeouts are in milliseconds.
+// Fields left nil are removed from the section by ReplaceFrontend, so replace a frontend obtained from GetFrontend.
 type Frontend struct {
 	DefaultBackend *string `json:"default_backend,omitempty"`
 	Description    *string `json:"description,omitempty"`
@@ -18,35 +128,103 @@ type Frontend struct {
 	Enabled        *bool   `json:"enabled,omitempty"`
 	Id             *int    `json:"id,omitempty"`
 	Name           *string `json:"name,omitempty"`
-	Mode           *string `json:"mode"`
+	Mode           *string `json:"mode,omitempty"`
+	From           *string `json:"from,omitempty"`
+	Maxconn        *int    `json:"maxconn,omitempty"`
+	Backlog        *int    `json:"backlog,omitempty"`
+
+	ClientTimeout        *int `json:"client_timeout,omitempty"`
+	ClientFinTimeout     *int `json:"client_fin_timeout,omitempty"`
+	HttpKeepAliveTimeout *int `json:"http_keep_alive_timeout,omitempty"`
+	HttpRequestTimeout   *int `json:"http_request_timeout,omitempty"`
+	TarpitTimeout        *int `json:"tarpit_timeout,omitempty"`
+
+	HttpConnectionMode *string      `json:"http_connection_mode,omitempty"`
+	HttpBufferRequest  *string      `json:"http-buffer-request,omitempty"`
+	Forwardfor         *Forwardfor  `json:"forwardfor,omitempty"`
+	Compression        *Compression `json:"compression,omitempty"`
+	Clitcpka           *string      `json:"clitcpka,omitempty"`
+	Nolinger           *string      `json:"nolinger,omitempty"`
+
+	Httplog           *bool   `json:"httplog,omitempty"`
+	Httpslog          *string `json:"httpslog,omitempty"`
+	Tcplog            *bool   `json:"tcplog,omitempty"`
+	Clflog            *bool   `json:"clflog,omitempty"`
+	LogFormat         *string `json:"log_format,omitempty"`
+	LogFormatSd       *string `json:"log_format_sd,omitempty"`
+	ErrorLogFormat    *string `json:"error_log_format,omitempty"`
+	LogTag            *string `json:"log_tag,omitempty"`
+	LogSeparateErrors *string `json:"log_separate_errors,omitempty"`
+	Logasap           *string `json:"logasap,omitempty"`
+	Dontlognull       *string `json:"dontlognull,omitempty"`
+	DontlogNormal     *string `json:"dontlog_normal,omitempty"`
+	UniqueIdFormat    *string `json:"unique_id_format,omitempty"`
+	UniqueIdHeader    *string `json:"unique_id_header,omitempty"`
+
+	StatsOptions *StatsOptions `json:"stats_options,omitempty"`
+	MonitorUri   *string       `json:"monitor_uri,omitempty"`
+	MonitorFail  *MonitorFail  `json:"monitor_fail,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (f Frontend) MarshalJSON() ([]byte, error) {
+	type plain Frontend
+	return marshalWithExtra(plain(f), f.Extra)
+}
+
+func (f *Frontend) UnmarshalJSON(data []byte) error {
+	type plain Frontend
+	extra, err := unmarshalWithExtra(data, (*plain)(f))
+	if err != nil {
+		return err
+	}
+
+	f.Extra = extra
+	return nil
+}
+
+func (c Client) AddFrontend(ctx context.Context, frontend Frontend, transactionId string) (*Frontend, error) {
+	return c.inTransaction(transactionId).AddFrontend(ctx, frontend)
+}
+
+func (c Client) GetFrontend(ctx context.Context, name string, transactionId string) (*Frontend, error) {
+	return c.inTransaction(transactionId).GetFrontend(ctx, name)
+}
+
+func (c Client) ListFrontends(ctx context.Context, transactionId string) ([]Frontend, error) {
+	return c.inTransaction(transactionId).ListFrontends(ctx)
+}
+
+func (c Client) ReplaceFrontend(ctx context.Context, name string, frontend Frontend, transactionId string) (*Frontend, error) {
+	return c.inTransaction(transactionId).ReplaceFrontend(ctx, name, frontend)
+}
+
+func (c Client) DeleteFrontend(ctx context.Context, name string, transactionId string) error {
+	return c.inTransaction(transactionId).DeleteFrontend(ctx, name)
 }
 
-func (c Client) AddFrontend(frontend Frontend, transactionId string) (*Frontend, error) {
-	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/frontends?transaction_id=%s", c.BaseUrl, transactionId)
+func (cfg *Configuration) AddFrontend(ctx context.Context, frontend Frontend) (*Frontend, error) {
+	apiUrl := cfg.writeUrl("frontends")
 
 	body, err := json.Marshal(frontend)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
 	}
 
-	return c.executeApiReturnsFrontend(apiUrl, "POST", bytes.NewReader(body))
+	return cfg.executeApiReturnsFrontend(ctx, apiUrl, "POST", bytes.NewReader(body))
 }
 
-func (c Client) GetFrontend(name string, transactionId string) (*Frontend, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s?transaction_id=%s",
-		c.BaseUrl,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) GetFrontend(ctx context.Context, name string) (*Frontend, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("frontends/%s", name))
 
-	return c.executeApiReturnsFrontend(apiUrl, "GET", nil)
+	return cfg.executeApiReturnsFrontend(ctx, apiUrl, "GET", nil)
 }
 
-func (c Client) ListFrontends(transactionId string) ([]Frontend, error) {
-	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/frontends?transaction_id=%s", c.BaseUrl, transactionId)
+func (cfg *Configuration) ListFrontends(ctx context.Context) ([]Frontend, error) {
+	apiUrl := cfg.readUrl("frontends")
 
-	resTxt, err := c.callApi(apiUrl, "GET", nil)
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -63,37 +241,27 @@ func (c Client) ListFrontends(transactionId string) ([]Frontend, error) {
 	return resResult, nil
 }
 
-func (c Client) ReplaceFrontend(name string, frontend Frontend, transactionId string) (*Frontend, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s?transaction_id=%s",
-		c.BaseUrl,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) ReplaceFrontend(ctx context.Context, name string, frontend Frontend) (*Frontend, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("frontends/%s", name))
 
 	body, err := json.Marshal(frontend)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
 	}
 
-	return c.executeApiReturnsFrontend(apiUrl, "PUT", bytes.NewReader(body))
+	return cfg.executeApiReturnsFrontend(ctx, apiUrl, "PUT", bytes.NewReader(body))
 }
 
-func (c Client) DeleteFrontend(name string, transactionId string) error {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/frontends/%s?transaction_id=%s",
-		c.BaseUrl,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) DeleteFrontend(ctx context.Context, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("frontends/%s", name))
 
-	_, err := c.callApi(apiUrl, "DELETE", nil)
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
 
 	return err
 }
 
-func (c Client) executeApiReturnsFrontend(apiUrl string, method string, body io.Reader) (*Frontend, error) {
-	resTxt, err := c.callApi(apiUrl, method, body)
+func (cfg *Configuration) executeApiReturnsFrontend(ctx context.Context, apiUrl string, method string, body io.Reader) (*Frontend, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
 	if err != nil {
 		return nil, err
 	}
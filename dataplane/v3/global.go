@@ -0,0 +1,187 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// RuntimeApi is a "stats socket" line of the global section exposing the runtime API.
+// It takes the socket address and every bind parameter, ssl or accept_proxy included, so it is a Bind;
+// the JSON methods of Bind also keep the properties this library does not model.
+type RuntimeApi struct {
+	Bind
+}
+
+// CpuMap is a cpu-map line of the global section, e.g. Process "1/all" and CpuSet "0-3"
+type CpuMap struct {
+	Process *string `json:"process,omitempty"`
+	CpuSet  *string `json:"cpu_set,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (c CpuMap) MarshalJSON() ([]byte, error) {
+	type plain CpuMap
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *CpuMap) UnmarshalJSON(data []byte) error {
+	type plain CpuMap
+	extra, err := unmarshalWithExtra(data, (*plain)(c))
+	if err != nil {
+		return err
+	}
+
+	c.Extra = extra
+	return nil
+}
+
+// LogTarget is a log line of the global section
+type LogTarget struct {
+	Address     *string `json:"address,omitempty"`
+	Facility    *string `json:"facility,omitempty"`
+	Format      *string `json:"format,omitempty"`
+	Length      *int    `json:"length,omitempty"`
+	Level       *string `json:"level,omitempty"`
+	Minlevel    *string `json:"minlevel,omitempty"`
+	Nolog       *bool   `json:"nolog,omitempty"`
+	SampleRange *string `json:"sample_range,omitempty"`
+	SampleSize  *int    `json:"sample_size,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (l LogTarget) MarshalJSON() ([]byte, error) {
+	type plain LogTarget
+	return marshalWithExtra(plain(l), l.Extra)
+}
+
+func (l *LogTarget) UnmarshalJSON(data []byte) error {
+	type plain LogTarget
+	extra, err := unmarshalWithExtra(data, (*plain)(l))
+	if err != nil {
+		return err
+	}
+
+	l.Extra = extra
+	return nil
+}
+
+// GlobalTuneOptions holds the tune.* settings of the global section
+type GlobalTuneOptions struct {
+	Bufsize                *int    `json:"bufsize,omitempty"`
+	Maxrewrite             *int    `json:"maxrewrite,omitempty"`
+	HttpMaxhdr             *int    `json:"http_maxhdr,omitempty"`
+	Maxaccept              *int    `json:"maxaccept,omitempty"`
+	Maxpollevents          *int    `json:"maxpollevents,omitempty"`
+	IdlePoolShared         *string `json:"idle_pool_shared,omitempty"`
+	H2MaxConcurrentStreams *int    `json:"h2_max_concurrent_streams,omitempty"`
+	SslCachesize           *int    `json:"ssl_cachesize,omitempty"`
+	SslDefaultDhParam      *int    `json:"ssl_default_dh_param,omitempty"`
+	SslLifetime            *int    `json:"ssl_lifetime,omitempty"`
+	SslMaxrecord           *int    `json:"ssl_maxrecord,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (t GlobalTuneOptions) MarshalJSON() ([]byte, error) {
+	type plain GlobalTuneOptions
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+func (t *GlobalTuneOptions) UnmarshalJSON(data []byte) error {
+	type plain GlobalTuneOptions
+	extra, err := unmarshalWithExtra(data, (*plain)(t))
+	if err != nil {
+		return err
+	}
+
+	t.Extra = extra
+	return nil
+}
+
+// Global is the global section. There is exactly one, so it is read and replaced but never added or deleted.
+type Global struct {
+	Chroot        *string `json:"chroot,omitempty"`
+	User          *string `json:"user,omitempty"`
+	Group         *string `json:"group,omitempty"`
+	Daemon        *bool   `json:"daemon,omitempty"`
+	MasterWorker  *bool   `json:"master-worker,omitempty"`
+	Pidfile       *string `json:"pidfile,omitempty"`
+	Localpeer     *string `json:"localpeer,omitempty"`
+	HardStopAfter *int    `json:"hard_stop_after,omitempty"`
+	StatsTimeout  *int    `json:"stats_timeout,omitempty"`
+
+	Maxconn  *int `json:"maxconn,omitempty"`
+	Nbthread *int `json:"nbthread,omitempty"`
+
+	SslDefaultBindOptions        *string `json:"ssl_default_bind_options,omitempty"`
+	SslDefaultBindCiphers        *string `json:"ssl_default_bind_ciphers,omitempty"`
+	SslDefaultBindCiphersuites   *string `json:"ssl_default_bind_ciphersuites,omitempty"`
+	SslDefaultServerOptions      *string `json:"ssl_default_server_options,omitempty"`
+	SslDefaultServerCiphers      *string `json:"ssl_default_server_ciphers,omitempty"`
+	SslDefaultServerCiphersuites *string `json:"ssl_default_server_ciphersuites,omitempty"`
+	SslDhParamFile               *string `json:"ssl_dh_param_file,omitempty"`
+
+	TuneOptions *GlobalTuneOptions `json:"tune_options,omitempty"`
+	RuntimeApis []RuntimeApi       `json:"runtime_apis,omitempty"`
+	CpuMaps     []CpuMap           `json:"cpu_maps,omitempty"`
+	LogTargets  []LogTarget        `json:"log_target_list,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (g Global) MarshalJSON() ([]byte, error) {
+	type plain Global
+	return marshalWithExtra(plain(g), g.Extra)
+}
+
+func (g *Global) UnmarshalJSON(data []byte) error {
+	type plain Global
+	extra, err := unmarshalWithExtra(data, (*plain)(g))
+	if err != nil {
+		return err
+	}
+
+	g.Extra = extra
+	return nil
+}
+
+// GetGlobal reads the global section; client.Current().GetGlobal reads the live one outside a transaction
+func (cfg *Configuration) GetGlobal(ctx context.Context) (*Global, error) {
+	apiUrl := cfg.readUrl("global")
+
+	return cfg.executeApiReturnsGlobal(ctx, apiUrl, "GET", nil)
+}
+
+// ReplaceGlobal replaces the whole global section; fields left nil are removed, so replace a value obtained from GetGlobal
+func (cfg *Configuration) ReplaceGlobal(ctx context.Context, global Global) (*Global, error) {
+	apiUrl := cfg.writeUrl("global")
+
+	body, err := json.Marshal(global)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsGlobal(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) executeApiReturnsGlobal(ctx context.Context, apiUrl string, method string, body io.Reader) (*Global, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult Global
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
@@ -0,0 +1,158 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	HTTP_AFTER_RESPONSE_RULE_TYPE_ADD_HEADER     = "add-header"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_ALLOW          = "allow"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_DEL_HEADER     = "del-header"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_REPLACE_HEADER = "replace-header"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_REPLACE_VALUE  = "replace-value"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_SET_HEADER     = "set-header"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_SET_LOG_LEVEL  = "set-log-level"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_SET_STATUS     = "set-status"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_SET_VAR        = "set-var"
+	HTTP_AFTER_RESPONSE_RULE_TYPE_UNSET_VAR      = "unset-var"
+)
+
+// HttpAfterResponseRule is an http-after-response line of a frontend, backend or defaults section,
+// addressed by its index within the section. Unlike http-response rules, these also apply to responses generated by HAProxy.
+type HttpAfterResponseRule struct {
+	Type     *string `json:"type,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	// set-header, add-header, del-header, replace-header, replace-value
+	HdrName   *string `json:"hdr_name,omitempty"`
+	HdrFormat *string `json:"hdr_format,omitempty"`
+	HdrMatch  *string `json:"hdr_match,omitempty"`
+	HdrMethod *string `json:"hdr_method,omitempty"`
+
+	// set-status
+	Status       *int    `json:"status,omitempty"`
+	StatusReason *string `json:"status_reason,omitempty"`
+
+	// set-var, unset-var
+	VarName   *string `json:"var_name,omitempty"`
+	VarScope  *string `json:"var_scope,omitempty"`
+	VarExpr   *string `json:"var_expr,omitempty"`
+	VarFormat *string `json:"var_format,omitempty"`
+
+	// set-log-level
+	LogLevel *string `json:"log_level,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r HttpAfterResponseRule) MarshalJSON() ([]byte, error) {
+	type plain HttpAfterResponseRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *HttpAfterResponseRule) UnmarshalJSON(data []byte) error {
+	type plain HttpAfterResponseRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddHttpAfterResponseRule inserts the rule at index; the rules from index onwards move down by one
+func (cfg *Configuration) AddHttpAfterResponseRule(ctx context.Context, parentType string, parentName string, index int, rule HttpAfterResponseRule) (*HttpAfterResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_after_response_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpAfterResponseRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetHttpAfterResponseRule(ctx context.Context, parentType string, parentName string, index int) (*HttpAfterResponseRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_after_response_rules/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsHttpAfterResponseRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListHttpAfterResponseRules(ctx context.Context, parentType string, parentName string) ([]HttpAfterResponseRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_after_response_rules", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsHttpAfterResponseRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceHttpAfterResponseRule(ctx context.Context, parentType string, parentName string, index int, rule HttpAfterResponseRule) (*HttpAfterResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_after_response_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpAfterResponseRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllHttpAfterResponseRules replaces every http-after-response rule of the section with rules, in order
+func (cfg *Configuration) ReplaceAllHttpAfterResponseRules(ctx context.Context, parentType string, parentName string, rules []HttpAfterResponseRule) ([]HttpAfterResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_after_response_rules", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpAfterResponseRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteHttpAfterResponseRule(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_after_response_rules/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsHttpAfterResponseRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*HttpAfterResponseRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult HttpAfterResponseRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsHttpAfterResponseRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]HttpAfterResponseRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []HttpAfterResponseRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
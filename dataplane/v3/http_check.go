@@ -0,0 +1,203 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	HTTP_CHECK_TYPE_COMMENT        = "comment"
+	HTTP_CHECK_TYPE_CONNECT        = "connect"
+	HTTP_CHECK_TYPE_DISABLE_ON_404 = "disable-on-404"
+	HTTP_CHECK_TYPE_EXPECT         = "expect"
+	HTTP_CHECK_TYPE_SEND           = "send"
+	HTTP_CHECK_TYPE_SEND_STATE     = "send-state"
+	HTTP_CHECK_TYPE_SET_VAR        = "set-var"
+	HTTP_CHECK_TYPE_UNSET_VAR      = "unset-var"
+)
+
+const (
+	HTTP_CHECK_MATCH_FHDR    = "fhdr"
+	HTTP_CHECK_MATCH_HDR     = "hdr"
+	HTTP_CHECK_MATCH_RSTATUS = "rstatus"
+	HTTP_CHECK_MATCH_RSTRING = "rstring"
+	HTTP_CHECK_MATCH_STATUS  = "status"
+	HTTP_CHECK_MATCH_STRING  = "string"
+)
+
+// HttpCheck is an http-check step of a backend or defaults section, addressed by its index within the section.
+// The steps run in order when the section uses "option httpchk" (see EnableBackendHttpchk).
+type HttpCheck struct {
+	Type         *string `json:"type,omitempty"`
+	CheckComment *string `json:"check_comment,omitempty"`
+
+	// connect
+	Addr       *string `json:"addr,omitempty"`
+	Port       *int    `json:"port,omitempty"`
+	PortString *string `json:"port_string,omitempty"`
+	Alpn       *string `json:"alpn,omitempty"`
+	Sni        *string `json:"sni,omitempty"`
+	Ssl        *bool   `json:"ssl,omitempty"`
+	Linger     *bool   `json:"linger,omitempty"`
+	SendProxy  *bool   `json:"send_proxy,omitempty"`
+	ViaSocks4  *bool   `json:"via_socks4,omitempty"`
+	Proto      *string `json:"proto,omitempty"`
+	Default    *bool   `json:"default,omitempty"`
+
+	// send
+	Method        *string        `json:"method,omitempty"`
+	Uri           *string        `json:"uri,omitempty"`
+	UriLogFormat  *string        `json:"uri_log_format,omitempty"`
+	Version       *string        `json:"version,omitempty"`
+	Headers       []ReturnHeader `json:"headers,omitempty"`
+	Body          *string        `json:"body,omitempty"`
+	BodyLogFormat *string        `json:"body_log_format,omitempty"`
+
+	// expect
+	Match           *string `json:"match,omitempty"`
+	Pattern         *string `json:"pattern,omitempty"`
+	ExclamationMark *bool   `json:"exclamation_mark,omitempty"`
+	MinRecv         *int    `json:"min_recv,omitempty"`
+	OkStatus        *string `json:"ok_status,omitempty"`
+	ErrorStatus     *string `json:"error_status,omitempty"`
+	ToutStatus      *string `json:"tout_status,omitempty"`
+	OnSuccess       *string `json:"on_success,omitempty"`
+	OnError         *string `json:"on_error,omitempty"`
+	StatusCode      *string `json:"status_code,omitempty"`
+
+	// set-var, unset-var
+	VarName   *string `json:"var_name,omitempty"`
+	VarScope  *string `json:"var_scope,omitempty"`
+	VarExpr   *string `json:"var_expr,omitempty"`
+	VarFormat *string `json:"var_format,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (h HttpCheck) MarshalJSON() ([]byte, error) {
+	type plain HttpCheck
+	return marshalWithExtra(plain(h), h.Extra)
+}
+
+func (h *HttpCheck) UnmarshalJSON(data []byte) error {
+	type plain HttpCheck
+	extra, err := unmarshalWithExtra(data, (*plain)(h))
+	if err != nil {
+		return err
+	}
+
+	h.Extra = extra
+	return nil
+}
+
+// AddHttpCheck inserts the check step at index; the steps from index onwards move down by one
+func (cfg *Configuration) AddHttpCheck(ctx context.Context, parentType string, parentName string, index int, check HttpCheck) (*HttpCheck, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_checks/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(check)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpCheck(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetHttpCheck(ctx context.Context, parentType string, parentName string, index int) (*HttpCheck, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_checks/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsHttpCheck(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListHttpChecks(ctx context.Context, parentType string, parentName string) ([]HttpCheck, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_checks", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsHttpChecks(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceHttpCheck(ctx context.Context, parentType string, parentName string, index int, check HttpCheck) (*HttpCheck, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_checks/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(check)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpCheck(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllHttpChecks replaces every http-check step of the section with checks, in order
+func (cfg *Configuration) ReplaceAllHttpChecks(ctx context.Context, parentType string, parentName string, checks []HttpCheck) ([]HttpCheck, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_checks", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(checks)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpChecks(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteHttpCheck(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_checks/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+// EnableBackendHttpchk turns on "option httpchk" for the backend with the given request parameters,
+// keeping the rest of the backend unchanged
+func (cfg *Configuration) EnableBackendHttpchk(ctx context.Context, backend string, params BackendHttpchkParams) (*Backend, error) {
+	current, err := cfg.GetBackend(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, &InvalidResponseError{Message: "empty backend payload"}
+	}
+
+	advCheck := BACKEND_ADV_CHECK_HTTPCHK
+	current.AdvCheck = &advCheck
+	current.HttpchkParams = &params
+
+	return cfg.ReplaceBackend(ctx, backend, *current)
+}
+
+func (cfg *Configuration) executeApiReturnsHttpCheck(ctx context.Context, apiUrl string, method string, body io.Reader) (*HttpCheck, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult HttpCheck
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsHttpChecks(ctx context.Context, apiUrl string, method string, body io.Reader) ([]HttpCheck, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []HttpCheck
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
@@ -0,0 +1,232 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	HTTP_REQUEST_RULE_TYPE_ADD_HEADER     = "add-header"
+	HTTP_REQUEST_RULE_TYPE_ALLOW          = "allow"
+	HTTP_REQUEST_RULE_TYPE_AUTH           = "auth"
+	HTTP_REQUEST_RULE_TYPE_DEL_HEADER     = "del-header"
+	HTTP_REQUEST_RULE_TYPE_DENY           = "deny"
+	HTTP_REQUEST_RULE_TYPE_REDIRECT       = "redirect"
+	HTTP_REQUEST_RULE_TYPE_REPLACE_HEADER = "replace-header"
+	HTTP_REQUEST_RULE_TYPE_REPLACE_PATH   = "replace-path"
+	HTTP_REQUEST_RULE_TYPE_REPLACE_URI    = "replace-uri"
+	HTTP_REQUEST_RULE_TYPE_RETURN         = "return"
+	HTTP_REQUEST_RULE_TYPE_SET_HEADER     = "set-header"
+	HTTP_REQUEST_RULE_TYPE_SET_LOG_LEVEL  = "set-log-level"
+	HTTP_REQUEST_RULE_TYPE_SET_METHOD     = "set-method"
+	HTTP_REQUEST_RULE_TYPE_SET_PATH       = "set-path"
+	HTTP_REQUEST_RULE_TYPE_SET_QUERY      = "set-query"
+	HTTP_REQUEST_RULE_TYPE_SET_URI        = "set-uri"
+	HTTP_REQUEST_RULE_TYPE_SET_VAR        = "set-var"
+	HTTP_REQUEST_RULE_TYPE_TARPIT         = "tarpit"
+	HTTP_REQUEST_RULE_TYPE_TRACK_SC       = "track-sc"
+	HTTP_REQUEST_RULE_TYPE_UNSET_VAR      = "unset-var"
+)
+
+const (
+	REDIRECT_TYPE_LOCATION = "location"
+	REDIRECT_TYPE_PREFIX   = "prefix"
+	REDIRECT_TYPE_SCHEME   = "scheme"
+)
+
+const (
+	VAR_SCOPE_PROC = "proc"
+	VAR_SCOPE_SESS = "sess"
+	VAR_SCOPE_TXN  = "txn"
+	VAR_SCOPE_REQ  = "req"
+	VAR_SCOPE_RES  = "res"
+)
+
+// ReturnHeader is a header added to the response of a "return" or "deny" rule
+type ReturnHeader struct {
+	Name *string `json:"name,omitempty"`
+	Fmt  *string `json:"fmt,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r ReturnHeader) MarshalJSON() ([]byte, error) {
+	type plain ReturnHeader
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *ReturnHeader) UnmarshalJSON(data []byte) error {
+	type plain ReturnHeader
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// HttpRequestRule is an http-request line of a frontend, backend or defaults section,
+// addressed by its index within the section. Type selects the action and which of the other fields apply.
+type HttpRequestRule struct {
+	Type     *string `json:"type,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	// set-header, add-header, del-header, replace-header
+	HdrName   *string `json:"hdr_name,omitempty"`
+	HdrFormat *string `json:"hdr_format,omitempty"`
+	HdrMatch  *string `json:"hdr_match,omitempty"`
+	HdrMethod *string `json:"hdr_method,omitempty"`
+
+	// redirect
+	RedirType   *string `json:"redir_type,omitempty"`
+	RedirValue  *string `json:"redir_value,omitempty"`
+	RedirCode   *int    `json:"redir_code,omitempty"`
+	RedirOption *string `json:"redir_option,omitempty"`
+
+	// deny, tarpit, return
+	DenyStatus          *int           `json:"deny_status,omitempty"`
+	ReturnStatusCode    *int           `json:"return_status_code,omitempty"`
+	ReturnContent       *string        `json:"return_content,omitempty"`
+	ReturnContentType   *string        `json:"return_content_type,omitempty"`
+	ReturnContentFormat *string        `json:"return_content_format,omitempty"`
+	ReturnHeaders       []ReturnHeader `json:"return_hdrs,omitempty"`
+
+	// set-path, replace-path, set-uri, replace-uri, set-query, set-method
+	PathFmt   *string `json:"path_fmt,omitempty"`
+	PathMatch *string `json:"path_match,omitempty"`
+	UriFmt    *string `json:"uri_fmt,omitempty"`
+	UriMatch  *string `json:"uri_match,omitempty"`
+	QueryFmt  *string `json:"query-fmt,omitempty"`
+	MethodFmt *string `json:"method_fmt,omitempty"`
+
+	// set-var, unset-var
+	VarName   *string `json:"var_name,omitempty"`
+	VarScope  *string `json:"var_scope,omitempty"`
+	VarExpr   *string `json:"var_expr,omitempty"`
+	VarFormat *string `json:"var_format,omitempty"`
+
+	// auth
+	AuthRealm *string `json:"auth_realm,omitempty"`
+
+	// track-sc
+	TrackScKey          *string `json:"track_sc_key,omitempty"`
+	TrackScTable        *string `json:"track_sc_table,omitempty"`
+	TrackScStickCounter *int    `json:"track_sc_stick_counter,omitempty"`
+
+	// set-log-level
+	LogLevel *string `json:"log_level,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r HttpRequestRule) MarshalJSON() ([]byte, error) {
+	type plain HttpRequestRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *HttpRequestRule) UnmarshalJSON(data []byte) error {
+	type plain HttpRequestRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddHttpRequestRule inserts the rule at index; the rules from index onwards move down by one
+func (cfg *Configuration) AddHttpRequestRule(ctx context.Context, parentType string, parentName string, index int, rule HttpRequestRule) (*HttpRequestRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_request_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpRequestRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetHttpRequestRule(ctx context.Context, parentType string, parentName string, index int) (*HttpRequestRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_request_rules/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsHttpRequestRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListHttpRequestRules(ctx context.Context, parentType string, parentName string) ([]HttpRequestRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_request_rules", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsHttpRequestRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceHttpRequestRule(ctx context.Context, parentType string, parentName string, index int, rule HttpRequestRule) (*HttpRequestRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_request_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpRequestRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllHttpRequestRules replaces every http-request rule of the section with rules, in order
+func (cfg *Configuration) ReplaceAllHttpRequestRules(ctx context.Context, parentType string, parentName string, rules []HttpRequestRule) ([]HttpRequestRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_request_rules", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpRequestRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteHttpRequestRule(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_request_rules/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsHttpRequestRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*HttpRequestRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult HttpRequestRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsHttpRequestRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]HttpRequestRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []HttpRequestRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
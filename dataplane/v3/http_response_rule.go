@@ -0,0 +1,181 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	HTTP_RESPONSE_RULE_TYPE_ADD_HEADER     = "add-header"
+	HTTP_RESPONSE_RULE_TYPE_ALLOW          = "allow"
+	HTTP_RESPONSE_RULE_TYPE_DEL_HEADER     = "del-header"
+	HTTP_RESPONSE_RULE_TYPE_DENY           = "deny"
+	HTTP_RESPONSE_RULE_TYPE_REDIRECT       = "redirect"
+	HTTP_RESPONSE_RULE_TYPE_REPLACE_HEADER = "replace-header"
+	HTTP_RESPONSE_RULE_TYPE_REPLACE_VALUE  = "replace-value"
+	HTTP_RESPONSE_RULE_TYPE_RETURN         = "return"
+	HTTP_RESPONSE_RULE_TYPE_SET_HEADER     = "set-header"
+	HTTP_RESPONSE_RULE_TYPE_SET_LOG_LEVEL  = "set-log-level"
+	HTTP_RESPONSE_RULE_TYPE_SET_STATUS     = "set-status"
+	HTTP_RESPONSE_RULE_TYPE_SET_VAR        = "set-var"
+	HTTP_RESPONSE_RULE_TYPE_TRACK_SC       = "track-sc"
+	HTTP_RESPONSE_RULE_TYPE_UNSET_VAR      = "unset-var"
+)
+
+// HttpResponseRule is an http-response line of a frontend, backend or defaults section,
+// addressed by its index within the section. Type selects the action and which of the other fields apply.
+type HttpResponseRule struct {
+	Type     *string `json:"type,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	// set-header, add-header, del-header, replace-header, replace-value
+	HdrName   *string `json:"hdr_name,omitempty"`
+	HdrFormat *string `json:"hdr_format,omitempty"`
+	HdrMatch  *string `json:"hdr_match,omitempty"`
+	HdrMethod *string `json:"hdr_method,omitempty"`
+
+	// redirect
+	RedirType   *string `json:"redir_type,omitempty"`
+	RedirValue  *string `json:"redir_value,omitempty"`
+	RedirCode   *int    `json:"redir_code,omitempty"`
+	RedirOption *string `json:"redir_option,omitempty"`
+
+	// deny, return
+	DenyStatus          *int           `json:"deny_status,omitempty"`
+	ReturnStatusCode    *int           `json:"return_status_code,omitempty"`
+	ReturnContent       *string        `json:"return_content,omitempty"`
+	ReturnContentType   *string        `json:"return_content_type,omitempty"`
+	ReturnContentFormat *string        `json:"return_content_format,omitempty"`
+	ReturnHeaders       []ReturnHeader `json:"return_hdrs,omitempty"`
+
+	// set-status
+	Status       *int    `json:"status,omitempty"`
+	StatusReason *string `json:"status_reason,omitempty"`
+
+	// set-var, unset-var
+	VarName   *string `json:"var_name,omitempty"`
+	VarScope  *string `json:"var_scope,omitempty"`
+	VarExpr   *string `json:"var_expr,omitempty"`
+	VarFormat *string `json:"var_format,omitempty"`
+
+	// track-sc
+	TrackScKey          *string `json:"track_sc_key,omitempty"`
+	TrackScTable        *string `json:"track_sc_table,omitempty"`
+	TrackScStickCounter *int    `json:"track_sc_stick_counter,omitempty"`
+
+	// set-log-level
+	LogLevel *string `json:"log_level,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r HttpResponseRule) MarshalJSON() ([]byte, error) {
+	type plain HttpResponseRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *HttpResponseRule) UnmarshalJSON(data []byte) error {
+	type plain HttpResponseRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddHttpResponseRule inserts the rule at index; the rules from index onwards move down by one
+func (cfg *Configuration) AddHttpResponseRule(ctx context.Context, parentType string, parentName string, index int, rule HttpResponseRule) (*HttpResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_response_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpResponseRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetHttpResponseRule(ctx context.Context, parentType string, parentName string, index int) (*HttpResponseRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_response_rules/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsHttpResponseRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListHttpResponseRules(ctx context.Context, parentType string, parentName string) ([]HttpResponseRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/http_response_rules", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsHttpResponseRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceHttpResponseRule(ctx context.Context, parentType string, parentName string, index int, rule HttpResponseRule) (*HttpResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_response_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpResponseRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllHttpResponseRules replaces every http-response rule of the section with rules, in order
+func (cfg *Configuration) ReplaceAllHttpResponseRules(ctx context.Context, parentType string, parentName string, rules []HttpResponseRule) ([]HttpResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_response_rules", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsHttpResponseRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteHttpResponseRule(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/http_response_rules/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsHttpResponseRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*HttpResponseRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult HttpResponseRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsHttpResponseRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]HttpResponseRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []HttpResponseRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
@@ -0,0 +1,108 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Nameserver is a nameserver line of a resolvers section
+type Nameserver struct {
+	Name    *string `json:"name,omitempty"`
+	Address *string `json:"address,omitempty"`
+	Port    *int    `json:"port,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (n Nameserver) MarshalJSON() ([]byte, error) {
+	type plain Nameserver
+	return marshalWithExtra(plain(n), n.Extra)
+}
+
+func (n *Nameserver) UnmarshalJSON(data []byte) error {
+	type plain Nameserver
+	extra, err := unmarshalWithExtra(data, (*plain)(n))
+	if err != nil {
+		return err
+	}
+
+	n.Extra = extra
+	return nil
+}
+
+func (cfg *Configuration) AddNameserver(ctx context.Context, resolver string, nameserver Nameserver) (*Nameserver, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("resolvers/%s/nameservers", resolver))
+
+	body, err := json.Marshal(nameserver)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsNameserver(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetNameserver(ctx context.Context, resolver string, name string) (*Nameserver, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("resolvers/%s/nameservers/%s", resolver, name))
+
+	return cfg.executeApiReturnsNameserver(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListNameservers(ctx context.Context, resolver string) ([]Nameserver, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("resolvers/%s/nameservers", resolver))
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Nameserver
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) ReplaceNameserver(ctx context.Context, resolver string, name string, nameserver Nameserver) (*Nameserver, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("resolvers/%s/nameservers/%s", resolver, name))
+
+	body, err := json.Marshal(nameserver)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsNameserver(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) DeleteNameserver(ctx context.Context, resolver string, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("resolvers/%s/nameservers/%s", resolver, name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsNameserver(ctx context.Context, apiUrl string, method string, body io.Reader) (*Nameserver, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult Nameserver
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
@@ -0,0 +1,142 @@
+package v3
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	credential string
+	httpClient *http.Client
+	tlsConfig  *tls.Config
+	proxy      func(*http.Request) (*url.URL, error)
+	timeout    time.Duration
+	userAgent  string
+
+	transactionAttempts int
+	reloadPollInterval  time.Duration
+}
+
+// WithCredential sets the pre-encoded Basic authentication credential (base64 of "user:password")
+func WithCredential(credential string) ClientOption {
+	return func(o *clientOptions) {
+		o.credential = credential
+	}
+}
+
+// WithBasicAuth sets the Basic authentication credential from a username and password
+func WithBasicAuth(username string, password string) ClientOption {
+	return func(o *clientOptions) {
+		o.credential = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password)))
+	}
+}
+
+// WithHTTPClient uses the given HTTP client for every request instead of a dedicated one.
+// The client is copied, so later options such as WithTimeout never modify the caller's value.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to reach the Data Plane API,
+// e.g. RootCAs for an internal CA or Certificates for mutual TLS
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = config
+	}
+}
+
+// WithProxy sets the proxy function used to reach the Data Plane API (see http.Transport.Proxy)
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {
+	return func(o *clientOptions) {
+		o.proxy = proxy
+	}
+}
+
+// WithTimeout limits the time spent on a single request, including reading the response body
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTransactionAttempts sets how many times WithTransaction runs a unit of work whose commit
+// fails because of a configuration version conflict (default 3)
+func WithTransactionAttempts(attempts int) ClientOption {
+	return func(o *clientOptions) {
+		o.transactionAttempts = attempts
+	}
+}
+
+// WithReloadPollInterval sets how often WaitForReload checks the reload status (default 1s)
+func WithReloadPollInterval(interval time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.reloadPollInterval = interval
+	}
+}
+
+// NewClient creates a Client for the Data Plane API at baseUrl (e.g. "https://haproxy:5555").
+// Requests share one HTTP client, so connections are pooled across calls.
+func NewClient(baseUrl string, opts ...ClientOption) (*Client, error) {
+	o := clientOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		httpClient = &copied
+	}
+
+	if o.tlsConfig != nil || o.proxy != nil {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		transport, ok := base.(*http.Transport)
+		if !ok {
+			return nil, &InternalError{Message: "TLS and proxy options require the HTTP client to use an *http.Transport"}
+		}
+
+		transport = transport.Clone()
+		if o.tlsConfig != nil {
+			transport.TLSClientConfig = o.tlsConfig
+		}
+		if o.proxy != nil {
+			transport.Proxy = o.proxy
+		}
+		httpClient.Transport = transport
+	}
+
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+
+	return &Client{
+		Credential: o.credential,
+		BaseUrl:    strings.TrimRight(baseUrl, "/"),
+		httpClient: httpClient,
+		userAgent:  o.userAgent,
+
+		transactionAttempts: o.transactionAttempts,
+		reloadPollInterval:  o.reloadPollInterval,
+	}, nil
+}
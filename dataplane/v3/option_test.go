@@ -0,0 +1,116 @@
+package v3
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewClientLeavesCallerHTTPClientUnchanged(t *testing.T) {
+	transport := &http.Transport{}
+	caller := &http.Client{Transport: transport, Timeout: time.Minute}
+
+	client, err := NewClient("http://haproxy:5555", WithHTTPClient(caller), WithTimeout(time.Second), WithTLSConfig(&tls.Config{ServerName: "haproxy"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clone may set up HTTP/2 on the original transport, which fills TLSClientConfig, but never with the given config
+	if caller.Timeout != time.Minute || caller.Transport != transport || (transport.TLSClientConfig != nil && transport.TLSClientConfig.ServerName == "haproxy") {
+		t.Errorf("caller's client was modified: %+v", caller)
+	}
+	if got := client.getHttpClient(); got == caller || got.Timeout != time.Second {
+		t.Errorf("client uses %+v, want a copy with a 1s timeout", got)
+	}
+}
+
+func TestNewClientClonesDefaultTransport(t *testing.T) {
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+
+	tlsConfig := &tls.Config{ServerName: "haproxy"}
+	proxyUrl, _ := url.Parse("http://proxy:3128")
+	client, err := NewClient("http://haproxy:5555", WithTLSConfig(tlsConfig), WithProxy(http.ProxyURL(proxyUrl)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport, ok := client.getHttpClient().Transport.(*http.Transport)
+	if !ok || transport == defaultTransport {
+		t.Fatalf("transport = %#v, want a clone of http.DefaultTransport", client.getHttpClient().Transport)
+	}
+	if transport.TLSClientConfig != tlsConfig {
+		t.Errorf("TLSClientConfig = %v, want the given config", transport.TLSClientConfig)
+	}
+	request := &http.Request{URL: &url.URL{Scheme: "http", Host: "haproxy"}}
+	if proxy, err := transport.Proxy(request); err != nil || proxy.String() != "http://proxy:3128" {
+		t.Errorf("Proxy = %v, %v, want http://proxy:3128", proxy, err)
+	}
+
+	if defaultTransport.TLSClientConfig == tlsConfig {
+		t.Error("http.DefaultTransport uses the given TLS config")
+	}
+	if proxy, _ := defaultTransport.Proxy(request); proxy != nil && proxy.String() == "http://proxy:3128" {
+		t.Error("http.DefaultTransport uses the given proxy")
+	}
+}
+
+func TestNewClientRejectsTLSOnCustomRoundTripper(t *testing.T) {
+	custom := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("unused")
+	})}
+
+	for name, opt := range map[string]ClientOption{
+		"tls":   WithTLSConfig(&tls.Config{}),
+		"proxy": WithProxy(http.ProxyFromEnvironment),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient("http://haproxy:5555", WithHTTPClient(custom), opt)
+
+			var internalErr *InternalError
+			if !errors.As(err, &internalErr) {
+				t.Errorf("err = %v, want an InternalError", err)
+			}
+		})
+	}
+}
+
+func TestNewClientRequestHeadersAndBaseUrl(t *testing.T) {
+	var header http.Header
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, path = r.Header, r.URL.Path
+		fmt.Fprint(w, "1")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", WithUserAgent("deployer/1.0"), WithBasicAuth("admin", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/v3/services/haproxy/configuration/version" {
+		t.Errorf("path = %q, want the trailing slash of the base URL trimmed", path)
+	}
+	if got := header.Get("User-Agent"); got != "deployer/1.0" {
+		t.Errorf("User-Agent = %q, want deployer/1.0", got)
+	}
+	if username, password, ok := (&http.Request{Header: header}).BasicAuth(); !ok || username != "admin" || password != "secret" {
+		t.Errorf("basic auth = %q, %q, %v, want admin, secret", username, password, ok)
+	}
+}
@@ -0,0 +1,110 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// PeerEntry is a peer line of a peers section. The entry whose Name matches the local peer name
+// (Global.Localpeer, or the hostname by default) is the local HAProxy instance.
+type PeerEntry struct {
+	Name    *string `json:"name,omitempty"`
+	Address *string `json:"address,omitempty"`
+	Port    *int    `json:"port,omitempty"`
+	Shard   *int    `json:"shard,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (p PeerEntry) MarshalJSON() ([]byte, error) {
+	type plain PeerEntry
+	return marshalWithExtra(plain(p), p.Extra)
+}
+
+func (p *PeerEntry) UnmarshalJSON(data []byte) error {
+	type plain PeerEntry
+	extra, err := unmarshalWithExtra(data, (*plain)(p))
+	if err != nil {
+		return err
+	}
+
+	p.Extra = extra
+	return nil
+}
+
+func (cfg *Configuration) AddPeerEntry(ctx context.Context, peerSection string, peerEntry PeerEntry) (*PeerEntry, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/peer_entries", peerSection))
+
+	body, err := json.Marshal(peerEntry)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsPeerEntry(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetPeerEntry(ctx context.Context, peerSection string, name string) (*PeerEntry, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peers/%s/peer_entries/%s", peerSection, name))
+
+	return cfg.executeApiReturnsPeerEntry(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListPeerEntries(ctx context.Context, peerSection string) ([]PeerEntry, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peers/%s/peer_entries", peerSection))
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []PeerEntry
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) ReplacePeerEntry(ctx context.Context, peerSection string, name string, peerEntry PeerEntry) (*PeerEntry, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/peer_entries/%s", peerSection, name))
+
+	body, err := json.Marshal(peerEntry)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsPeerEntry(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) DeletePeerEntry(ctx context.Context, peerSection string, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/peer_entries/%s", peerSection, name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsPeerEntry(ctx context.Context, apiUrl string, method string, body io.Reader) (*PeerEntry, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult PeerEntry
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
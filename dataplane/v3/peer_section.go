@@ -0,0 +1,214 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// PeerSection is a peers section, the set of HAProxy instances that replicate stick tables with each other.
+// A stick table joins it through StickTable.Peers.
+// The Data Plane API cannot replace a peers section; change its peer entries, binds and servers instead.
+type PeerSection struct {
+	Name          *string        `json:"name,omitempty"`
+	DefaultBind   *Bind          `json:"default_bind,omitempty"`
+	DefaultServer *DefaultServer `json:"default_server,omitempty"`
+	Disabled      *bool          `json:"disabled,omitempty"`
+	Enabled       *bool          `json:"enabled,omitempty"`
+	Shards        *int           `json:"shards,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (p PeerSection) MarshalJSON() ([]byte, error) {
+	type plain PeerSection
+	return marshalWithExtra(plain(p), p.Extra)
+}
+
+func (p *PeerSection) UnmarshalJSON(data []byte) error {
+	type plain PeerSection
+	extra, err := unmarshalWithExtra(data, (*plain)(p))
+	if err != nil {
+		return err
+	}
+
+	p.Extra = extra
+	return nil
+}
+
+func (cfg *Configuration) AddPeerSection(ctx context.Context, peerSection PeerSection) (*PeerSection, error) {
+	apiUrl := cfg.writeUrl("peer_section")
+
+	body, err := json.Marshal(peerSection)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsPeerSection(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetPeerSection(ctx context.Context, name string) (*PeerSection, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peer_section/%s", name))
+
+	return cfg.executeApiReturnsPeerSection(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListPeerSections(ctx context.Context) ([]PeerSection, error) {
+	apiUrl := cfg.readUrl("peer_section")
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []PeerSection
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) DeletePeerSection(ctx context.Context, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peer_section/%s", name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsPeerSection(ctx context.Context, apiUrl string, method string, body io.Reader) (*PeerSection, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult PeerSection
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) AddPeerBind(ctx context.Context, peerSection string, bind Bind) (*Bind, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/binds", peerSection))
+
+	body, err := json.Marshal(bind)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsBind(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetPeerBind(ctx context.Context, peerSection string, name string) (*Bind, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peers/%s/binds/%s", peerSection, name))
+
+	return cfg.executeApiReturnsBind(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListPeerBinds(ctx context.Context, peerSection string) ([]Bind, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peers/%s/binds", peerSection))
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Bind
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) ReplacePeerBind(ctx context.Context, peerSection string, name string, bind Bind) (*Bind, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/binds/%s", peerSection, name))
+
+	body, err := json.Marshal(bind)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsBind(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) DeletePeerBind(ctx context.Context, peerSection string, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/binds/%s", peerSection, name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) AddPeerServer(ctx context.Context, peerSection string, server Server) (*Server, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/servers", peerSection))
+
+	body, err := json.Marshal(server)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsServer(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetPeerServer(ctx context.Context, peerSection string, name string) (*Server, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peers/%s/servers/%s", peerSection, name))
+
+	return cfg.executeApiReturnsServer(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListPeerServers(ctx context.Context, peerSection string) ([]Server, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("peers/%s/servers", peerSection))
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Server
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) ReplacePeerServer(ctx context.Context, peerSection string, name string, server Server) (*Server, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/servers/%s", peerSection, name))
+
+	body, err := json.Marshal(server)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsServer(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) DeletePeerServer(ctx context.Context, peerSection string, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("peers/%s/servers/%s", peerSection, name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
@@ -0,0 +1,110 @@
+package v3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	RELOAD_STATUS_FAILED      = "failed"
+	RELOAD_STATUS_IN_PROGRESS = "in_progress"
+	RELOAD_STATUS_SUCCEEDED   = "succeeded"
+)
+
+// RELOAD_ID_HEADER is the response header carrying the ID of the reload triggered by a change
+const RELOAD_ID_HEADER = "Reload-ID"
+
+// defaultReloadPollInterval is used by WaitForReload when the client does not set WithReloadPollInterval
+const defaultReloadPollInterval = time.Second
+
+type Reload struct {
+	Id              *string `json:"id,omitempty"`
+	Status          *string `json:"status,omitempty"`
+	ReloadTimestamp *int64  `json:"reload_timestamp,omitempty"`
+	Response        *string `json:"response,omitempty"`
+}
+
+func (c Client) GetReload(ctx context.Context, id string) (*Reload, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/reloads/%s", c.BaseUrl, id)
+
+	return c.executeApiReturnsReload(ctx, apiUrl, "GET", nil)
+}
+
+func (c Client) ListReloads(ctx context.Context) ([]Reload, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/reloads", c.BaseUrl)
+
+	resTxt, err := c.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Reload
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+// WaitForReload polls the reload until HAProxy reports it succeeded or failed.
+// A failed reload is returned together with a ReloadFailedError.
+func (c Client) WaitForReload(ctx context.Context, id string) (*Reload, error) {
+	interval := c.reloadPollInterval
+	if interval <= 0 {
+		interval = defaultReloadPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		reload, err := c.GetReload(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if reload != nil && reload.Status != nil {
+			switch *reload.Status {
+			case RELOAD_STATUS_SUCCEEDED:
+				return reload, nil
+			case RELOAD_STATUS_FAILED:
+				message := ""
+				if reload.Response != nil {
+					message = *reload.Response
+				}
+				return reload, &ReloadFailedError{Message: message, ReloadID: id}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, contextError(ctx, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c Client) executeApiReturnsReload(ctx context.Context, apiUrl string, method string, body io.Reader) (*Reload, error) {
+	resTxt, err := c.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult Reload
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
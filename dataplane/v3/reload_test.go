@@ -0,0 +1,118 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeReloads answers the status of reload "r1" with statuses in turn, repeating the last one
+func fakeReloads(t *testing.T, statuses ...string) (*Client, func() int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	polls := 0
+	client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/services/haproxy/reloads/r1" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mu.Lock()
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"id":"r1","status":%q,"response":"[ALERT] config invalid"}`, status)
+	}), WithReloadPollInterval(5*time.Millisecond))
+
+	return client, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return polls
+	}
+}
+
+func TestWaitForReloadSucceeds(t *testing.T) {
+	client, polls := fakeReloads(t, RELOAD_STATUS_IN_PROGRESS, RELOAD_STATUS_IN_PROGRESS, RELOAD_STATUS_SUCCEEDED)
+
+	reload, err := client.WaitForReload(context.Background(), "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reload == nil || reload.Status == nil || *reload.Status != RELOAD_STATUS_SUCCEEDED {
+		t.Errorf("reload = %+v, want succeeded", reload)
+	}
+	if polls() != 3 {
+		t.Errorf("polls = %d, want 3", polls())
+	}
+}
+
+func TestWaitForReloadFails(t *testing.T) {
+	client, _ := fakeReloads(t, RELOAD_STATUS_IN_PROGRESS, RELOAD_STATUS_FAILED)
+
+	reload, err := client.WaitForReload(context.Background(), "r1")
+	if !IsReloadFailed(err) {
+		t.Fatalf("err = %v, want a ReloadFailedError", err)
+	}
+
+	var reloadErr *ReloadFailedError
+	if errors.As(err, &reloadErr) && (reloadErr.Message != "[ALERT] config invalid" || reloadErr.ReloadID != "r1") {
+		t.Errorf("ReloadFailedError = %+v, want the reload response and ID", reloadErr)
+	}
+	if reload == nil || reload.Status == nil || *reload.Status != RELOAD_STATUS_FAILED {
+		t.Errorf("reload = %+v, want the failed reload", reload)
+	}
+}
+
+func TestWaitForReloadCanceled(t *testing.T) {
+	client, polls := fakeReloads(t, RELOAD_STATUS_IN_PROGRESS)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	reload, err := client.WaitForReload(ctx, "r1")
+	if !IsCanceled(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want a CanceledError wrapping context.DeadlineExceeded", err)
+	}
+	if reload != nil {
+		t.Errorf("reload = %+v, want nil", reload)
+	}
+	if polls() < 2 {
+		t.Errorf("polls = %d, want polling until the deadline", polls())
+	}
+}
+
+func TestWaitForReloadGetError(t *testing.T) {
+	client, _ := fakeReloads(t, RELOAD_STATUS_SUCCEEDED)
+
+	reload, err := client.WaitForReload(context.Background(), "unknown")
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v, want a NotFoundError", err)
+	}
+	if reload != nil {
+		t.Errorf("reload = %+v, want nil", reload)
+	}
+}
+
+func TestCommitTransactionReturnsReloadId(t *testing.T) {
+	client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RELOAD_ID_HEADER, "r1")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"tx-1","status":"success"}`)
+	}))
+
+	committed, err := client.CommitTransaction(context.Background(), "tx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if committed.ReloadId != "r1" {
+		t.Errorf("ReloadId = %q, want r1", committed.ReloadId)
+	}
+}
@@ -0,0 +1,118 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Resolver is a resolvers section, a set of DNS nameservers that servers reference through ServerParams.Resolvers.
+// Durations are in milliseconds.
+type Resolver struct {
+	Name                *string `json:"name,omitempty"`
+	AcceptedPayloadSize *int    `json:"accepted_payload_size,omitempty"`
+	ParseResolvConf     *bool   `json:"parse-resolv-conf,omitempty"`
+	ResolveRetries      *int    `json:"resolve_retries,omitempty"`
+	TimeoutResolve      *int    `json:"timeout_resolve,omitempty"`
+	TimeoutRetry        *int    `json:"timeout_retry,omitempty"`
+	HoldNx              *int    `json:"hold_nx,omitempty"`
+	HoldObsolete        *int    `json:"hold_obsolete,omitempty"`
+	HoldOther           *int    `json:"hold_other,omitempty"`
+	HoldRefused         *int    `json:"hold_refused,omitempty"`
+	HoldTimeout         *int    `json:"hold_timeout,omitempty"`
+	HoldValid           *int    `json:"hold_valid,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r Resolver) MarshalJSON() ([]byte, error) {
+	type plain Resolver
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *Resolver) UnmarshalJSON(data []byte) error {
+	type plain Resolver
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+func (cfg *Configuration) AddResolver(ctx context.Context, resolver Resolver) (*Resolver, error) {
+	apiUrl := cfg.writeUrl("resolvers")
+
+	body, err := json.Marshal(resolver)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsResolver(ctx, apiUrl, "POST", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) GetResolver(ctx context.Context, name string) (*Resolver, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("resolvers/%s", name))
+
+	return cfg.executeApiReturnsResolver(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListResolvers(ctx context.Context) ([]Resolver, error) {
+	apiUrl := cfg.readUrl("resolvers")
+
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []Resolver
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (cfg *Configuration) ReplaceResolver(ctx context.Context, name string, resolver Resolver) (*Resolver, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("resolvers/%s", name))
+
+	body, err := json.Marshal(resolver)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsResolver(ctx, apiUrl, "PUT", bytes.NewReader(body))
+}
+
+func (cfg *Configuration) DeleteResolver(ctx context.Context, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("resolvers/%s", name))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsResolver(ctx context.Context, apiUrl string, method string, body io.Reader) (*Resolver, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult Resolver
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
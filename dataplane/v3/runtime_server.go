@@ -0,0 +1,102 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Values of RuntimeServer.AdminState
+const (
+	RUNTIME_SERVER_ADMIN_STATE_READY = "ready"
+	RUNTIME_SERVER_ADMIN_STATE_MAINT = "maint"
+	RUNTIME_SERVER_ADMIN_STATE_DRAIN = "drain"
+)
+
+// Values of RuntimeServer.OperationalState
+const (
+	RUNTIME_SERVER_OPERATIONAL_STATE_UP       = "up"
+	RUNTIME_SERVER_OPERATIONAL_STATE_DOWN     = "down"
+	RUNTIME_SERVER_OPERATIONAL_STATE_STOPPING = "stopping"
+)
+
+// RuntimeServer is the live state of a server in the running HAProxy.
+// Only AdminState and OperationalState can be changed; the change takes effect immediately, without a transaction
+// or a reload, and is lost on the next reload unless the configuration is changed as well.
+// Use SetServerWeight and SetServerAddress to change the weight, address or port of a server without a reload.
+type RuntimeServer struct {
+	Id               *string `json:"id,omitempty"`
+	Name             *string `json:"name,omitempty"`
+	Address          *string `json:"address,omitempty"`
+	Port             *int    `json:"port,omitempty"`
+	AdminState       *string `json:"admin_state,omitempty"`
+	OperationalState *string `json:"operational_state,omitempty"`
+}
+
+func (c Client) GetRuntimeServer(ctx context.Context, backend string, name string) (*RuntimeServer, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/backends/%s/servers/%s", c.BaseUrl, backend, name)
+
+	return c.executeApiReturnsRuntimeServer(ctx, apiUrl, "GET", nil)
+}
+
+func (c Client) ListRuntimeServers(ctx context.Context, backend string) ([]RuntimeServer, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/backends/%s/servers", c.BaseUrl, backend)
+
+	resTxt, err := c.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []RuntimeServer
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+// ReplaceRuntimeServer applies the AdminState and OperationalState set in server to the running server;
+// an unset state is left as it is, and the other fields are not sent since HAProxy ignores them.
+// Setting OperationalState (RUNTIME_SERVER_OPERATIONAL_STATE_*) overrides the health check result
+// until the next check changes it again.
+func (c Client) ReplaceRuntimeServer(ctx context.Context, backend string, name string, server RuntimeServer) (*RuntimeServer, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/backends/%s/servers/%s", c.BaseUrl, backend, name)
+
+	reqBody, err := json.Marshal(RuntimeServer{AdminState: server.AdminState, OperationalState: server.OperationalState})
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return c.executeApiReturnsRuntimeServer(ctx, apiUrl, "PUT", bytes.NewBuffer(reqBody))
+}
+
+// SetRuntimeServerAdminState puts the server in ready, drain or maint (RUNTIME_SERVER_ADMIN_STATE_*).
+// A drained server finishes its current sessions and accepts no new ones except persistent ones;
+// a server in maint accepts no traffic at all.
+func (c Client) SetRuntimeServerAdminState(ctx context.Context, backend string, name string, adminState string) (*RuntimeServer, error) {
+	return c.ReplaceRuntimeServer(ctx, backend, name, RuntimeServer{AdminState: &adminState})
+}
+
+func (c Client) executeApiReturnsRuntimeServer(ctx context.Context, apiUrl string, method string, body io.Reader) (*RuntimeServer, error) {
+	resTxt, err := c.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult RuntimeServer
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
@@ -0,0 +1,257 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Data types stored in stick tables, usable in StickTableFilter.DataType and ClearStickTableEntry
+const (
+	STICK_TABLE_DATA_BYTES_IN_CNT   = "bytes_in_cnt"
+	STICK_TABLE_DATA_BYTES_IN_RATE  = "bytes_in_rate"
+	STICK_TABLE_DATA_BYTES_OUT_CNT  = "bytes_out_cnt"
+	STICK_TABLE_DATA_BYTES_OUT_RATE = "bytes_out_rate"
+	STICK_TABLE_DATA_CONN_CNT       = "conn_cnt"
+	STICK_TABLE_DATA_CONN_CUR       = "conn_cur"
+	STICK_TABLE_DATA_CONN_RATE      = "conn_rate"
+	STICK_TABLE_DATA_GPC0           = "gpc0"
+	STICK_TABLE_DATA_GPC0_RATE      = "gpc0_rate"
+	STICK_TABLE_DATA_GPC1           = "gpc1"
+	STICK_TABLE_DATA_GPC1_RATE      = "gpc1_rate"
+	STICK_TABLE_DATA_GPT0           = "gpt0"
+	STICK_TABLE_DATA_HTTP_ERR_CNT   = "http_err_cnt"
+	STICK_TABLE_DATA_HTTP_ERR_RATE  = "http_err_rate"
+	STICK_TABLE_DATA_HTTP_REQ_CNT   = "http_req_cnt"
+	STICK_TABLE_DATA_HTTP_REQ_RATE  = "http_req_rate"
+	STICK_TABLE_DATA_SERVER_ID      = "server_id"
+	STICK_TABLE_DATA_SESS_CNT       = "sess_cnt"
+	STICK_TABLE_DATA_SESS_RATE      = "sess_rate"
+)
+
+// Operators comparing a stored value with StickTableFilter.Value
+const (
+	STICK_TABLE_FILTER_EQ = "eq"
+	STICK_TABLE_FILTER_NE = "ne"
+	STICK_TABLE_FILTER_LE = "le"
+	STICK_TABLE_FILTER_LT = "lt"
+	STICK_TABLE_FILTER_GE = "ge"
+	STICK_TABLE_FILTER_GT = "gt"
+)
+
+// RuntimeStickTable is a stick table as seen by the running HAProxy, with its current fill level.
+// Stick tables are configured through Backend.StickTable.
+type RuntimeStickTable struct {
+	Name   *string           `json:"name,omitempty"`
+	Type   *string           `json:"type,omitempty"`
+	Size   *int              `json:"size,omitempty"`
+	Used   *int              `json:"used,omitempty"`
+	Fields []StickTableField `json:"fields,omitempty"`
+}
+
+// StickTableField is a data type stored by a stick table; Period is set for rates
+type StickTableField struct {
+	Field  *string `json:"field,omitempty"`
+	Type   *string `json:"type,omitempty"`
+	Period *int    `json:"period,omitempty"`
+}
+
+// StickTableEntry is an entry of a stick table. Only the data types the table stores are set.
+type StickTableEntry struct {
+	Key          *string `json:"key,omitempty"`
+	Id           *string `json:"id,omitempty"`
+	Use          *bool   `json:"use,omitempty"`
+	Exp          *int    `json:"exp,omitempty"`
+	BytesInCnt   *int    `json:"bytes_in_cnt,omitempty"`
+	BytesInRate  *int    `json:"bytes_in_rate,omitempty"`
+	BytesOutCnt  *int    `json:"bytes_out_cnt,omitempty"`
+	BytesOutRate *int    `json:"bytes_out_rate,omitempty"`
+	ConnCnt      *int    `json:"conn_cnt,omitempty"`
+	ConnCur      *int    `json:"conn_cur,omitempty"`
+	ConnRate     *int    `json:"conn_rate,omitempty"`
+	Gpc0         *int    `json:"gpc0,omitempty"`
+	Gpc0Rate     *int    `json:"gpc0_rate,omitempty"`
+	Gpc1         *int    `json:"gpc1,omitempty"`
+	Gpc1Rate     *int    `json:"gpc1_rate,omitempty"`
+	Gpt0         *int    `json:"gpt0,omitempty"`
+	HttpErrCnt   *int    `json:"http_err_cnt,omitempty"`
+	HttpErrRate  *int    `json:"http_err_rate,omitempty"`
+	HttpReqCnt   *int    `json:"http_req_cnt,omitempty"`
+	HttpReqRate  *int    `json:"http_req_rate,omitempty"`
+	ServerId     *int    `json:"server_id,omitempty"`
+	SessCnt      *int    `json:"sess_cnt,omitempty"`
+	SessRate     *int    `json:"sess_rate,omitempty"`
+}
+
+// StickTableFilter keeps the entries whose DataType value compares to Value with Operator,
+// e.g. {STICK_TABLE_DATA_HTTP_REQ_RATE, STICK_TABLE_FILTER_GT, 100}
+type StickTableFilter struct {
+	DataType string
+	Operator string
+	Value    int
+}
+
+// StickTableEntriesQuery narrows ListStickTableEntries. The zero value lists every entry.
+type StickTableEntriesQuery struct {
+	// Key selects the single entry with this key
+	Key string
+	// Filters must all match; HAProxy accepts up to 4 of them
+	Filters []StickTableFilter
+	// Count limits the number of returned entries when not 0
+	Count int
+}
+
+func (q StickTableEntriesQuery) values() url.Values {
+	query := url.Values{}
+	if q.Key != "" {
+		query.Set("key", q.Key)
+	}
+	if len(q.Filters) != 0 {
+		filters := make([]string, 0, len(q.Filters))
+		for _, f := range q.Filters {
+			filters = append(filters, fmt.Sprintf("data.%s %s %d", f.DataType, f.Operator, f.Value))
+		}
+		query.Set("filter", strings.Join(filters, ","))
+	}
+	if q.Count != 0 {
+		query.Set("count", strconv.Itoa(q.Count))
+	}
+
+	return query
+}
+
+func (c Client) ListStickTables(ctx context.Context) ([]RuntimeStickTable, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/stick_tables", c.BaseUrl)
+
+	resTxt, err := c.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []RuntimeStickTable
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+func (c Client) GetStickTable(ctx context.Context, name string) (*RuntimeStickTable, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/stick_tables/%s", c.BaseUrl, name)
+
+	return c.executeApiReturnsRuntimeStickTable(ctx, apiUrl, "GET", nil)
+}
+
+func (c Client) ListStickTableEntries(ctx context.Context, table string, query StickTableEntriesQuery) ([]StickTableEntry, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/stick_tables/%s/entries", c.BaseUrl, table)
+	if values := query.values(); len(values) != 0 {
+		apiUrl = fmt.Sprintf("%s?%s", apiUrl, values.Encode())
+	}
+
+	resTxt, err := c.callApi(ctx, apiUrl, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []StickTableEntry
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
+
+// SetStickTableEntry creates the entry for key, or updates it, with the data types set in data.
+// Data types the table does not store are rejected by HAProxy.
+func (c Client) SetStickTableEntry(ctx context.Context, table string, key string, data StickTableEntry) error {
+	data.Key = nil
+	data.Id = nil
+	data.Use = nil
+	data.Exp = nil
+
+	return c.setStickTableEntry(ctx, table, key, data)
+}
+
+// ClearStickTableEntry resets the given data types of the entry for key to 0, e.g. to unblock a client
+// denied on gpc0 or http_req_rate. Without data types, every counter and rate the table stores is reset;
+// server_id, which keeps the client stuck to its server, and the live conn_cur gauge are left as they are
+// unless they are given explicitly.
+// The Data Plane API cannot remove an entry; a cleared entry stays until it expires.
+func (c Client) ClearStickTableEntry(ctx context.Context, table string, key string, dataTypes ...string) error {
+	if len(dataTypes) == 0 {
+		stickTable, err := c.GetStickTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if stickTable != nil {
+			for _, field := range stickTable.Fields {
+				if field.Field == nil || !clearedByDefault(*field.Field) {
+					continue
+				}
+				dataTypes = append(dataTypes, *field.Field)
+			}
+		}
+	}
+
+	if len(dataTypes) == 0 {
+		return nil
+	}
+
+	data := map[string]int{}
+	for _, dataType := range dataTypes {
+		data[dataType] = 0
+	}
+
+	return c.setStickTableEntry(ctx, table, key, data)
+}
+
+// clearedByDefault reports whether ClearStickTableEntry resets dataType when no data types are given
+func clearedByDefault(dataType string) bool {
+	return dataType != STICK_TABLE_DATA_SERVER_ID && dataType != STICK_TABLE_DATA_CONN_CUR
+}
+
+func (c Client) setStickTableEntry(ctx context.Context, table string, key string, data any) error {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/runtime/stick_tables/%s/entries", c.BaseUrl, table)
+
+	reqBody, err := json.Marshal(map[string]any{
+		"key":       key,
+		"data_type": data,
+	})
+	if err != nil {
+		return &InvalidResponseError{Message: err.Error()}
+	}
+
+	_, err = c.callApi(ctx, apiUrl, "POST", bytes.NewBuffer(reqBody))
+	return err
+}
+
+func (c Client) executeApiReturnsRuntimeStickTable(ctx context.Context, apiUrl string, method string, body io.Reader) (*RuntimeStickTable, error) {
+	resTxt, err := c.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult RuntimeStickTable
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
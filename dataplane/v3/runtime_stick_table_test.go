@@ -0,0 +1,53 @@
+package v3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClearStickTableEntry(t *testing.T) {
+	tests := []struct {
+		name      string
+		dataTypes []string
+		want      map[string]int
+	}{
+		{"stored counters", nil, map[string]int{"gpc0": 0, "http_req_rate": 0}},
+		{"explicit data types", []string{STICK_TABLE_DATA_SERVER_ID}, map[string]int{"server_id": 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted struct {
+				Key      string         `json:"key"`
+				DataType map[string]int `json:"data_type"`
+			}
+			client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/services/haproxy/runtime/stick_tables/web":
+					fmt.Fprint(w, `{"name":"web","type":"ip","fields":[{"field":"server_id"},{"field":"conn_cur"},{"field":"gpc0"},{"field":"http_req_rate","period":10000}]}`)
+				case r.Method == http.MethodPost && r.URL.Path == "/v3/services/haproxy/runtime/stick_tables/web/entries":
+					body, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(body, &posted); err != nil {
+						t.Errorf("invalid body %s: %v", body, err)
+					}
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			if err := client.ClearStickTableEntry(context.Background(), "web", "10.0.0.1", tt.dataTypes...); err != nil {
+				t.Fatal(err)
+			}
+
+			if posted.Key != "10.0.0.1" || !reflect.DeepEqual(posted.DataType, tt.want) {
+				t.Errorf("posted %+v, want key 10.0.0.1 and data types %v", posted, tt.want)
+			}
+		})
+	}
+}
@@ -2,55 +2,174 @@ package v3
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 )
 
+const (
+	SERVER_VERIFY_NONE     = "none"
+	SERVER_VERIFY_REQUIRED = "required"
+)
+
+const (
+	SERVER_ON_ERROR_FASTINTER    = "fastinter"
+	SERVER_ON_ERROR_FAIL_CHECK   = "fail-check"
+	SERVER_ON_ERROR_MARK_DOWN    = "mark-down"
+	SERVER_ON_ERROR_SUDDEN_DEATH = "sudden-death"
+)
+
+const (
+	SERVER_RESOLVE_PREFER_IPV4 = "ipv4"
+	SERVER_RESOLVE_PREFER_IPV6 = "ipv6"
+)
+
+const (
+	SERVER_ON_MARKED_DOWN_SHUTDOWN_SESSIONS      = "shutdown-sessions"
+	SERVER_ON_MARKED_UP_SHUTDOWN_BACKUP_SESSIONS = "shutdown-backup-sessions"
+)
+
+// ServerParams holds the parameters shared by servers and default-server lines.
+// Options such as Check, Backup or Ssl take OPTION_ENABLED or OPTION_DISABLED; durations are in milliseconds.
+type ServerParams struct {
+	Weight    *int    `json:"weight,omitempty"`
+	Backup    *string `json:"backup,omitempty"`
+	Cookie    *string `json:"cookie,omitempty"`
+	Maxconn   *int    `json:"maxconn,omitempty"`
+	Maxqueue  *int    `json:"maxqueue,omitempty"`
+	Minconn   *int    `json:"minconn,omitempty"`
+	Slowstart *int    `json:"slowstart,omitempty"`
+	Track     *string `json:"track,omitempty"`
+
+	Maintenance  *string `json:"maintenance,omitempty"`
+	OnError      *string `json:"on-error,omitempty"`
+	OnMarkedDown *string `json:"on-marked-down,omitempty"`
+	OnMarkedUp   *string `json:"on-marked-up,omitempty"`
+	Observe      *string `json:"observe,omitempty"`
+	ErrorLimit   *int    `json:"error_limit,omitempty"`
+
+	Check           *string `json:"check,omitempty"`
+	CheckAlpn       *string `json:"check_alpn,omitempty"`
+	CheckProto      *string `json:"check_proto,omitempty"`
+	CheckSendProxy  *string `json:"check-send-proxy,omitempty"`
+	CheckSni        *string `json:"check-sni,omitempty"`
+	CheckSsl        *string `json:"check-ssl,omitempty"`
+	HealthCheckPort *int    `json:"health_check_port,omitempty"`
+	Inter           *int    `json:"inter,omitempty"`
+	Fastinter       *int    `json:"fastinter,omitempty"`
+	Downinter       *int    `json:"downinter,omitempty"`
+	Rise            *int    `json:"rise,omitempty"`
+	Fall            *int    `json:"fall,omitempty"`
+
+	AgentCheck *string `json:"agent-check,omitempty"`
+	AgentAddr  *string `json:"agent-addr,omitempty"`
+	AgentInter *int    `json:"agent-inter,omitempty"`
+	AgentPort  *int    `json:"agent-port,omitempty"`
+	AgentSend  *string `json:"agent-send,omitempty"`
+
+	Ssl            *string `json:"ssl,omitempty"`
+	SslCertificate *string `json:"ssl_certificate,omitempty"`
+	SslMaxVer      *string `json:"ssl_max_ver,omitempty"`
+	SslMinVer      *string `json:"ssl_min_ver,omitempty"`
+	SslReuse       *string `json:"ssl_reuse,omitempty"`
+	Ciphers        *string `json:"ciphers,omitempty"`
+	Ciphersuites   *string `json:"ciphersuites,omitempty"`
+	Verify         *string `json:"verify,omitempty"`
+	Verifyhost     *string `json:"verifyhost,omitempty"`
+	CaFile         *string `json:"ca_file,omitempty"`
+	CrlFile        *string `json:"crl_file,omitempty"`
+	Sni            *string `json:"sni,omitempty"`
+	Alpn           *string `json:"alpn,omitempty"`
+	Proto          *string `json:"proto,omitempty"`
+
+	SendProxy        *string  `json:"send-proxy,omitempty"`
+	SendProxyV2      *string  `json:"send-proxy-v2,omitempty"`
+	SendProxyV2Ssl   *string  `json:"send-proxy-v2-ssl,omitempty"`
+	SendProxyV2SslCn *string  `json:"send-proxy-v2-ssl-cn,omitempty"`
+	ProxyV2Options   []string `json:"proxy-v2-options,omitempty"`
+	PoolMaxConn      *int     `json:"pool_max_conn,omitempty"`
+	PoolPurgeDelay   *int     `json:"pool_purge_delay,omitempty"`
+	MaxReuse         *int     `json:"max_reuse,omitempty"`
+	Tfo              *string  `json:"tfo,omitempty"`
+	Ws               *string  `json:"ws,omitempty"`
+	Namespace        *string  `json:"namespace,omitempty"`
+
+	Resolvers     *string `json:"resolvers,omitempty"`
+	ResolvePrefer *string `json:"resolve-prefer,omitempty"`
+	ResolveNet    *string `json:"resolve-net,omitempty"`
+	ResolveOpts   *string `json:"resolve_opts,omitempty"`
+	InitAddr      *string `json:"init-addr,omitempty"`
+}
+
+// Server is a server line of a backend
 type Server struct {
-	Id      *string `json:"id,omitempty"`
+	ServerParams
+	Id      *int    `json:"id,omitempty"`
 	Name    *string `json:"name,omitempty"`
 	Address *string `json:"address,omitempty"`
 	Port    *int    `json:"port,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (s Server) MarshalJSON() ([]byte, error) {
+	type plain Server
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	extra, err := unmarshalWithExtra(data, (*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	s.Extra = extra
+	return nil
+}
+
+func (c Client) AddServer(ctx context.Context, backend string, transactionId string, server Server) (*Server, error) {
+	return c.inTransaction(transactionId).AddServer(ctx, backend, server)
+}
+
+func (c Client) GetServer(ctx context.Context, name string, backend string, transactionId string) (*Server, error) {
+	return c.inTransaction(transactionId).GetServer(ctx, backend, name)
+}
+
+func (c Client) ListServers(ctx context.Context, backend string, transactionId string) ([]Server, error) {
+	return c.inTransaction(transactionId).ListServers(ctx, backend)
+}
+
+func (c Client) ReplaceServer(ctx context.Context, backend string, transactionId string, server Server) (*Server, error) {
+	return c.inTransaction(transactionId).ReplaceServer(ctx, backend, server)
 }
 
-func (c Client) AddServer(backend string, transactionId string, server Server) (*Server, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s/servers?transaction_id=%s",
-		c.BaseUrl,
-		backend,
-		transactionId,
-	)
+func (c Client) DeleteServer(ctx context.Context, name string, backend string, transactionId string) error {
+	return c.inTransaction(transactionId).DeleteServer(ctx, backend, name)
+}
+
+func (cfg *Configuration) AddServer(ctx context.Context, backend string, server Server) (*Server, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("backends/%s/servers", backend))
 
 	reqTxt, err := json.Marshal(server)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
 	}
 
-	return c.executeApiReturnsServer(apiUrl, "POST", bytes.NewReader(reqTxt))
+	return cfg.executeApiReturnsServer(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
 }
 
-func (c Client) GetServer(name string, backend string, transactionId string) (*Server, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s/servers/%s?transaction_id=%s",
-		c.BaseUrl,
-		backend,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) GetServer(ctx context.Context, backend string, name string) (*Server, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("backends/%s/servers/%s", backend, name))
 
-	return c.executeApiReturnsServer(apiUrl, "GET", nil)
+	return cfg.executeApiReturnsServer(ctx, apiUrl, "GET", nil)
 }
 
-func (c Client) ListServers(backend string, transactionId string) ([]Server, error) {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s/servers?transaction_id=%s",
-		c.BaseUrl,
-		backend,
-		transactionId,
-	)
+func (cfg *Configuration) ListServers(ctx context.Context, backend string) ([]Server, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("backends/%s/servers", backend))
 
-	resTxt, err := c.callApi(apiUrl, "GET", nil)
+	resTxt, err := cfg.callApi(ctx, apiUrl, "GET", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -67,43 +186,70 @@ func (c Client) ListServers(backend string, transactionId string) ([]Server, err
 	return resResult, nil
 }
 
-func (c Client) ReplaceServer(backend string, transactionId string, server Server) (*Server, error) {
+func (cfg *Configuration) ReplaceServer(ctx context.Context, backend string, server Server) (*Server, error) {
 	if server.Name == nil {
 		return nil, fmt.Errorf("server name is required")
 	}
-	
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s/servers/%s?transaction_id=%s",
-		c.BaseUrl,
-		backend,
-		*server.Name,
-		transactionId,
-	)
+
+	apiUrl := cfg.writeUrl(fmt.Sprintf("backends/%s/servers/%s", backend, *server.Name))
 
 	reqTxt, err := json.Marshal(server)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
 	}
 
-	return c.executeApiReturnsServer(apiUrl, "PUT", bytes.NewReader(reqTxt))
+	return cfg.executeApiReturnsServer(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// SetServerWeight changes the weight of a server in the configuration at the current version.
+// The Data Plane API applies a weight change through the runtime API, so HAProxy is not reloaded.
+// A change made concurrently by someone else fails it with a ConflictError; it can simply be called again.
+func (c Client) SetServerWeight(ctx context.Context, backend string, name string, weight int) (*Applied, error) {
+	return c.updateServer(ctx, backend, name, func(server *Server) {
+		server.Weight = &weight
+	})
+}
+
+// SetServerAddress changes the address and port of a server in the configuration at the current version.
+// Like SetServerWeight, the change is applied through the runtime API, without reloading HAProxy.
+func (c Client) SetServerAddress(ctx context.Context, backend string, name string, address string, port int) (*Applied, error) {
+	return c.updateServer(ctx, backend, name, func(server *Server) {
+		server.Address = &address
+		server.Port = &port
+	})
+}
+
+func (c Client) updateServer(ctx context.Context, backend string, name string, update func(server *Server)) (*Applied, error) {
+	version, err := c.GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ApplyAtVersion(ctx, *version, false, func(ctx context.Context, cfg *Configuration) error {
+		server, err := cfg.GetServer(ctx, backend, name)
+		if err != nil {
+			return err
+		}
+		if server == nil {
+			return &InvalidResponseError{Message: "server is missing"}
+		}
+
+		update(server)
+		_, err = cfg.ReplaceServer(ctx, backend, *server)
+		return err
+	})
 }
 
-func (c Client) DeleteServer(name string, backend string, transactionId string) error {
-	apiUrl := fmt.Sprintf(
-		"%s/v3/services/haproxy/configuration/backends/%s/servers/%s?transaction_id=%s",
-		c.BaseUrl,
-		backend,
-		name,
-		transactionId,
-	)
+func (cfg *Configuration) DeleteServer(ctx context.Context, backend string, name string) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("backends/%s/servers/%s", backend, name))
 
-	_, err := c.callApi(apiUrl, "DELETE", nil)
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
 
 	return err
 }
 
-func (c Client) executeApiReturnsServer(apiUrl string, method string, body io.Reader) (*Server, error) {
-	resTxt, err := c.callApi(apiUrl, method, body)
+func (cfg *Configuration) executeApiReturnsServer(ctx context.Context, apiUrl string, method string, body io.Reader) (*Server, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
 	if err != nil {
 		return nil, err
 	}
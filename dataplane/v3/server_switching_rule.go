@@ -0,0 +1,126 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ServerSwitchingRule is a use-server line of a backend, addressed by its index within the backend.
+// TargetServer is the server to use when the condition matches.
+type ServerSwitchingRule struct {
+	TargetServer *string `json:"target_server,omitempty"`
+	Cond         *string `json:"cond,omitempty"`
+	CondTest     *string `json:"cond_test,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r ServerSwitchingRule) MarshalJSON() ([]byte, error) {
+	type plain ServerSwitchingRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *ServerSwitchingRule) UnmarshalJSON(data []byte) error {
+	type plain ServerSwitchingRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddServerSwitchingRule inserts the rule at index; rules are evaluated in order and the first match wins
+func (cfg *Configuration) AddServerSwitchingRule(ctx context.Context, backend string, index int, rule ServerSwitchingRule) (*ServerSwitchingRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/server_switching_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsServerSwitchingRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetServerSwitchingRule(ctx context.Context, backend string, index int) (*ServerSwitchingRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/server_switching_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	return cfg.executeApiReturnsServerSwitchingRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListServerSwitchingRules(ctx context.Context, backend string) ([]ServerSwitchingRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/server_switching_rules", parentPath(PARENT_TYPE_BACKEND, backend)))
+
+	return cfg.executeApiReturnsServerSwitchingRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceServerSwitchingRule(ctx context.Context, backend string, index int, rule ServerSwitchingRule) (*ServerSwitchingRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/server_switching_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsServerSwitchingRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllServerSwitchingRules replaces every use-server rule of the backend with rules, in order
+func (cfg *Configuration) ReplaceAllServerSwitchingRules(ctx context.Context, backend string, rules []ServerSwitchingRule) ([]ServerSwitchingRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/server_switching_rules", parentPath(PARENT_TYPE_BACKEND, backend)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsServerSwitchingRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteServerSwitchingRule(ctx context.Context, backend string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/server_switching_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsServerSwitchingRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*ServerSwitchingRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult ServerSwitchingRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsServerSwitchingRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]ServerSwitchingRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []ServerSwitchingRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
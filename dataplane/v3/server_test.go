@@ -0,0 +1,40 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestSetServerWeightReplacesServerAtCurrentVersion(t *testing.T) {
+	var replaced string
+	client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v3/services/haproxy/configuration/version":
+			fmt.Fprint(w, "7")
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/services/haproxy/configuration/backends/web/servers/web1":
+			fmt.Fprint(w, `{"name":"web1","address":"10.0.0.1","port":80,"weight":100,"newthing":1}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/v3/services/haproxy/configuration/backends/web/servers/web1":
+			if got := r.URL.Query().Get("version"); got != "7" {
+				t.Errorf("version = %q, want 7", got)
+			}
+			body, _ := io.ReadAll(r.Body)
+			replaced = string(body)
+			fmt.Fprint(w, replaced)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	applied, err := client.SetServerWeight(context.Background(), "web", "web1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSameJSON(t, []byte(replaced), `{"name":"web1","address":"10.0.0.1","port":80,"weight":0,"newthing":1}`)
+	if applied.Version != 8 || len(applied.ReloadIds) != 0 {
+		t.Errorf("Applied = %+v, want version 8 and no reloads", applied)
+	}
+}
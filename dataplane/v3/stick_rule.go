@@ -0,0 +1,135 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	STICK_RULE_TYPE_MATCH          = "match"
+	STICK_RULE_TYPE_ON             = "on"
+	STICK_RULE_TYPE_STORE_REQUEST  = "store-request"
+	STICK_RULE_TYPE_STORE_RESPONSE = "store-response"
+)
+
+// StickRule is a stick line of a backend, addressed by its index within the backend.
+// Pattern is the sample expression stored in or matched against Table, which defaults to the backend's own stick table.
+type StickRule struct {
+	Type     *string `json:"type,omitempty"`
+	Pattern  *string `json:"pattern,omitempty"`
+	Table    *string `json:"table,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r StickRule) MarshalJSON() ([]byte, error) {
+	type plain StickRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *StickRule) UnmarshalJSON(data []byte) error {
+	type plain StickRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddStickRule inserts the rule at index; the rules from index onwards move down by one
+func (cfg *Configuration) AddStickRule(ctx context.Context, backend string, index int, rule StickRule) (*StickRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/stick_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsStickRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetStickRule(ctx context.Context, backend string, index int) (*StickRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/stick_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	return cfg.executeApiReturnsStickRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListStickRules(ctx context.Context, backend string) ([]StickRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/stick_rules", parentPath(PARENT_TYPE_BACKEND, backend)))
+
+	return cfg.executeApiReturnsStickRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceStickRule(ctx context.Context, backend string, index int, rule StickRule) (*StickRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/stick_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsStickRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllStickRules replaces every stick rule of the backend with rules, in order
+func (cfg *Configuration) ReplaceAllStickRules(ctx context.Context, backend string, rules []StickRule) ([]StickRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/stick_rules", parentPath(PARENT_TYPE_BACKEND, backend)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsStickRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteStickRule(ctx context.Context, backend string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/stick_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsStickRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*StickRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult StickRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsStickRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]StickRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []StickRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
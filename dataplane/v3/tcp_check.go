@@ -0,0 +1,183 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	TCP_CHECK_ACTION_COMMENT        = "comment"
+	TCP_CHECK_ACTION_CONNECT        = "connect"
+	TCP_CHECK_ACTION_EXPECT         = "expect"
+	TCP_CHECK_ACTION_SEND           = "send"
+	TCP_CHECK_ACTION_SEND_LF        = "send-lf"
+	TCP_CHECK_ACTION_SEND_BINARY    = "send-binary"
+	TCP_CHECK_ACTION_SEND_BINARY_LF = "send-binary-lf"
+	TCP_CHECK_ACTION_SET_VAR        = "set-var"
+	TCP_CHECK_ACTION_UNSET_VAR      = "unset-var"
+)
+
+const (
+	TCP_CHECK_MATCH_BINARY    = "binary"
+	TCP_CHECK_MATCH_BINARY_LF = "binary-lf"
+	TCP_CHECK_MATCH_RBINARY   = "rbinary"
+	TCP_CHECK_MATCH_RSTRING   = "rstring"
+	TCP_CHECK_MATCH_STRING    = "string"
+	TCP_CHECK_MATCH_STRING_LF = "string-lf"
+)
+
+// TcpCheck is a tcp-check step of a backend or defaults section, addressed by its index within the section.
+// The steps run in order when the section uses "option tcp-check" (Backend.AdvCheck BACKEND_ADV_CHECK_TCP_CHECK).
+type TcpCheck struct {
+	Action       *string `json:"action,omitempty"`
+	CheckComment *string `json:"check_comment,omitempty"`
+
+	// connect
+	Addr       *string `json:"addr,omitempty"`
+	Port       *int    `json:"port,omitempty"`
+	PortString *string `json:"port_string,omitempty"`
+	Alpn       *string `json:"alpn,omitempty"`
+	Sni        *string `json:"sni,omitempty"`
+	Ssl        *bool   `json:"ssl,omitempty"`
+	Linger     *bool   `json:"linger,omitempty"`
+	SendProxy  *bool   `json:"send_proxy,omitempty"`
+	ViaSocks4  *bool   `json:"via_socks4,omitempty"`
+	Proto      *string `json:"proto,omitempty"`
+	Default    *bool   `json:"default,omitempty"`
+
+	// send, send-lf, send-binary, send-binary-lf
+	Data      *string `json:"data,omitempty"`
+	Fmt       *string `json:"fmt,omitempty"`
+	HexString *string `json:"hex_string,omitempty"`
+	HexFmt    *string `json:"hex_fmt,omitempty"`
+
+	// expect
+	Match           *string `json:"match,omitempty"`
+	Pattern         *string `json:"pattern,omitempty"`
+	ExclamationMark *bool   `json:"exclamation_mark,omitempty"`
+	MinRecv         *int    `json:"min_recv,omitempty"`
+	OkStatus        *string `json:"ok_status,omitempty"`
+	ErrorStatus     *string `json:"error_status,omitempty"`
+	ToutStatus      *string `json:"tout_status,omitempty"`
+	OnSuccess       *string `json:"on_success,omitempty"`
+	OnError         *string `json:"on_error,omitempty"`
+	StatusCode      *string `json:"status_code,omitempty"`
+
+	// set-var, unset-var
+	VarName  *string `json:"var_name,omitempty"`
+	VarScope *string `json:"var_scope,omitempty"`
+	VarExpr  *string `json:"var_expr,omitempty"`
+	VarFmt   *string `json:"var_fmt,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (t TcpCheck) MarshalJSON() ([]byte, error) {
+	type plain TcpCheck
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+func (t *TcpCheck) UnmarshalJSON(data []byte) error {
+	type plain TcpCheck
+	extra, err := unmarshalWithExtra(data, (*plain)(t))
+	if err != nil {
+		return err
+	}
+
+	t.Extra = extra
+	return nil
+}
+
+// AddTcpCheck inserts the check step at index; the steps from index onwards move down by one
+func (cfg *Configuration) AddTcpCheck(ctx context.Context, parentType string, parentName string, index int, check TcpCheck) (*TcpCheck, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_checks/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(check)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpCheck(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetTcpCheck(ctx context.Context, parentType string, parentName string, index int) (*TcpCheck, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/tcp_checks/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsTcpCheck(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListTcpChecks(ctx context.Context, parentType string, parentName string) ([]TcpCheck, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/tcp_checks", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsTcpChecks(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceTcpCheck(ctx context.Context, parentType string, parentName string, index int, check TcpCheck) (*TcpCheck, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_checks/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(check)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpCheck(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllTcpChecks replaces every tcp-check step of the section with checks, in order
+func (cfg *Configuration) ReplaceAllTcpChecks(ctx context.Context, parentType string, parentName string, checks []TcpCheck) ([]TcpCheck, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_checks", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(checks)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpChecks(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteTcpCheck(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_checks/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsTcpCheck(ctx context.Context, apiUrl string, method string, body io.Reader) (*TcpCheck, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult TcpCheck
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsTcpChecks(ctx context.Context, apiUrl string, method string, body io.Reader) ([]TcpCheck, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []TcpCheck
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
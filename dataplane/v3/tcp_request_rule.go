@@ -0,0 +1,163 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	TCP_REQUEST_RULE_TYPE_CONNECTION    = "connection"
+	TCP_REQUEST_RULE_TYPE_CONTENT       = "content"
+	TCP_REQUEST_RULE_TYPE_INSPECT_DELAY = "inspect-delay"
+	TCP_REQUEST_RULE_TYPE_SESSION       = "session"
+)
+
+const (
+	TCP_RULE_ACTION_ACCEPT       = "accept"
+	TCP_RULE_ACTION_CLOSE        = "close"
+	TCP_RULE_ACTION_EXPECT_PROXY = "expect-proxy"
+	TCP_RULE_ACTION_REJECT       = "reject"
+	TCP_RULE_ACTION_SET_VAR      = "set-var"
+	TCP_RULE_ACTION_SILENT_DROP  = "silent-drop"
+	TCP_RULE_ACTION_TRACK_SC     = "track-sc"
+	TCP_RULE_ACTION_UNSET_VAR    = "unset-var"
+)
+
+// TcpRequestRule is a tcp-request line of a frontend or backend, addressed by its index within the section.
+// Type selects the processing stage; an inspect-delay rule only sets Timeout, the others perform Action.
+type TcpRequestRule struct {
+	Type     *string `json:"type,omitempty"`
+	Action   *string `json:"action,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	// inspect-delay, in milliseconds
+	Timeout *int `json:"timeout,omitempty"`
+
+	// track-sc
+	TrackKey          *string `json:"track_key,omitempty"`
+	TrackTable        *string `json:"track_table,omitempty"`
+	TrackStickCounter *int    `json:"track_stick_counter,omitempty"`
+
+	// set-var, unset-var
+	VarName   *string `json:"var_name,omitempty"`
+	VarScope  *string `json:"var_scope,omitempty"`
+	VarFormat *string `json:"var_format,omitempty"`
+	Expr      *string `json:"expr,omitempty"`
+
+	// capture
+	CaptureLen    *int    `json:"capture_len,omitempty"`
+	CaptureSample *string `json:"capture_sample,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r TcpRequestRule) MarshalJSON() ([]byte, error) {
+	type plain TcpRequestRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *TcpRequestRule) UnmarshalJSON(data []byte) error {
+	type plain TcpRequestRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddTcpRequestRule inserts the rule at index; the rules from index onwards move down by one
+func (cfg *Configuration) AddTcpRequestRule(ctx context.Context, parentType string, parentName string, index int, rule TcpRequestRule) (*TcpRequestRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_request_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpRequestRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetTcpRequestRule(ctx context.Context, parentType string, parentName string, index int) (*TcpRequestRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/tcp_request_rules/%d", parentPath(parentType, parentName), index))
+
+	return cfg.executeApiReturnsTcpRequestRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListTcpRequestRules(ctx context.Context, parentType string, parentName string) ([]TcpRequestRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/tcp_request_rules", parentPath(parentType, parentName)))
+
+	return cfg.executeApiReturnsTcpRequestRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceTcpRequestRule(ctx context.Context, parentType string, parentName string, index int, rule TcpRequestRule) (*TcpRequestRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_request_rules/%d", parentPath(parentType, parentName), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpRequestRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllTcpRequestRules replaces every tcp-request rule of the section with rules, in order
+func (cfg *Configuration) ReplaceAllTcpRequestRules(ctx context.Context, parentType string, parentName string, rules []TcpRequestRule) ([]TcpRequestRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_request_rules", parentPath(parentType, parentName)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpRequestRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteTcpRequestRule(ctx context.Context, parentType string, parentName string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_request_rules/%d", parentPath(parentType, parentName), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsTcpRequestRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*TcpRequestRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult TcpRequestRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsTcpRequestRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]TcpRequestRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []TcpRequestRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
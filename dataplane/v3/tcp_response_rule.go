@@ -0,0 +1,141 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	TCP_RESPONSE_RULE_TYPE_CONTENT       = "content"
+	TCP_RESPONSE_RULE_TYPE_INSPECT_DELAY = "inspect-delay"
+)
+
+// TcpResponseRule is a tcp-response line of a backend, addressed by its index within the backend.
+// An inspect-delay rule only sets Timeout; a content rule performs Action.
+type TcpResponseRule struct {
+	Type     *string `json:"type,omitempty"`
+	Action   *string `json:"action,omitempty"`
+	Cond     *string `json:"cond,omitempty"`
+	CondTest *string `json:"cond_test,omitempty"`
+
+	// inspect-delay, in milliseconds
+	Timeout *int `json:"timeout,omitempty"`
+
+	// set-var, unset-var
+	VarName   *string `json:"var_name,omitempty"`
+	VarScope  *string `json:"var_scope,omitempty"`
+	VarFormat *string `json:"var_format,omitempty"`
+	Expr      *string `json:"expr,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r TcpResponseRule) MarshalJSON() ([]byte, error) {
+	type plain TcpResponseRule
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *TcpResponseRule) UnmarshalJSON(data []byte) error {
+	type plain TcpResponseRule
+	extra, err := unmarshalWithExtra(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+	return nil
+}
+
+// AddTcpResponseRule inserts the rule at index; the rules from index onwards move down by one
+func (cfg *Configuration) AddTcpResponseRule(ctx context.Context, backend string, index int, rule TcpResponseRule) (*TcpResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_response_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpResponseRule(ctx, apiUrl, "POST", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) GetTcpResponseRule(ctx context.Context, backend string, index int) (*TcpResponseRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/tcp_response_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	return cfg.executeApiReturnsTcpResponseRule(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ListTcpResponseRules(ctx context.Context, backend string) ([]TcpResponseRule, error) {
+	apiUrl := cfg.readUrl(fmt.Sprintf("%s/tcp_response_rules", parentPath(PARENT_TYPE_BACKEND, backend)))
+
+	return cfg.executeApiReturnsTcpResponseRules(ctx, apiUrl, "GET", nil)
+}
+
+func (cfg *Configuration) ReplaceTcpResponseRule(ctx context.Context, backend string, index int, rule TcpResponseRule) (*TcpResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_response_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	reqTxt, err := json.Marshal(rule)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpResponseRule(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+// ReplaceAllTcpResponseRules replaces every tcp-response rule of the backend with rules, in order
+func (cfg *Configuration) ReplaceAllTcpResponseRules(ctx context.Context, backend string, rules []TcpResponseRule) ([]TcpResponseRule, error) {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_response_rules", parentPath(PARENT_TYPE_BACKEND, backend)))
+
+	reqTxt, err := json.Marshal(rules)
+	if err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return cfg.executeApiReturnsTcpResponseRules(ctx, apiUrl, "PUT", bytes.NewReader(reqTxt))
+}
+
+func (cfg *Configuration) DeleteTcpResponseRule(ctx context.Context, backend string, index int) error {
+	apiUrl := cfg.writeUrl(fmt.Sprintf("%s/tcp_response_rules/%d", parentPath(PARENT_TYPE_BACKEND, backend), index))
+
+	_, err := cfg.callApi(ctx, apiUrl, "DELETE", nil)
+
+	return err
+}
+
+func (cfg *Configuration) executeApiReturnsTcpResponseRule(ctx context.Context, apiUrl string, method string, body io.Reader) (*TcpResponseRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult TcpResponseRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return &resResult, nil
+}
+
+func (cfg *Configuration) executeApiReturnsTcpResponseRules(ctx context.Context, apiUrl string, method string, body io.Reader) ([]TcpResponseRule, error) {
+	resTxt, err := cfg.callApi(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
+	}
+
+	var resResult []TcpResponseRule
+	if err := json.Unmarshal(resTxt, &resResult); err != nil {
+		return nil, &InvalidResponseError{Message: err.Error()}
+	}
+
+	return resResult, nil
+}
@@ -1,10 +1,10 @@
 package v3
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strconv"
 	"strings"
 )
@@ -16,33 +16,45 @@ const (
 	TRANSACTION_STATUS_SUCCESS     = "success"
 )
 
+// defaultTransactionAttempts is used by WithTransaction when the client does not set WithTransactionAttempts
+const defaultTransactionAttempts = 3
+
 type Transaction struct {
 	Id     *string `json:"id,omitempty"`
 	Status *string `json:"status,omitempty"`
+	// ReloadId identifies the HAProxy reload triggered by committing the transaction (see WaitForReload)
+	ReloadId string `json:"-"`
 }
 
-func (c Client) GetVersion() (*int, error) {
-	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/version", c.BaseUrl)
-	req, err := http.NewRequest("GET", apiUrl, nil)
-	if err != nil {
-		return nil, &InternalError{Message: err.Error()}
-	}
+// Tx is a handle to an open transaction returned by CreateTransaction.
+// Configuration operations called on a Tx are bound to its transaction ID.
+type Tx struct {
+	Transaction
+	*Configuration
+}
 
-	req.Header = c.constructAuthorizationHeader()
-	req.Header.Add("Content-Type", "application/json")
-	client := new(http.Client)
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, &InvalidResponseError{Message: err.Error()}
-	}
-	defer res.Body.Close()
+func (tx *Tx) id() string {
+	return *tx.Id
+}
+
+func (tx *Tx) Commit(ctx context.Context) (*Transaction, error) {
+	return tx.client.CommitTransaction(ctx, tx.id())
+}
+
+func (tx *Tx) Close(ctx context.Context) error {
+	_, err := tx.client.CloseTransaction(ctx, tx.id())
+	return err
+}
+
+func (c Client) GetVersion(ctx context.Context) (*int, error) {
+	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/configuration/version", c.BaseUrl)
 
-	result, err := io.ReadAll(res.Body)
+	resTxt, err := c.callApi(ctx, apiUrl, "GET", nil)
 	if err != nil {
-		return nil, &InvalidResponseError{Message: err.Error()}
+		return nil, err
 	}
 
-	versionString := strings.TrimRight(string(result), "\n")
+	versionString := strings.TrimSpace(string(resTxt))
 	version, err := strconv.Atoi(versionString)
 	if err != nil {
 		return nil, &InvalidResponseError{Message: err.Error()}
@@ -51,48 +63,49 @@ func (c Client) GetVersion() (*int, error) {
 	return &version, nil
 }
 
-func (c Client) CreateTransaction(version int) (*Transaction, error) {
+func (c Client) CreateTransaction(ctx context.Context, version int) (*Tx, error) {
 	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/transactions?version=%d", c.BaseUrl, version)
 
-	req, err := http.NewRequest("POST", apiUrl, nil)
+	transaction, err := c.executeApiReturnsTransaction(ctx, apiUrl, "POST", nil)
 	if err != nil {
-		return nil, &InternalError{Message: err.Error()}
+		return nil, err
+	}
+	if transaction == nil || transaction.Id == nil {
+		return nil, &InvalidResponseError{Message: "transaction id is missing"}
 	}
 
-	req.Header = c.constructAuthorizationHeader()
-	req.Header.Add("Content-Type", "application/json")
-	return c.executeApiReturnsTransaction(req)
+	return &Tx{Transaction: *transaction, Configuration: c.inTransaction(*transaction.Id)}, nil
 }
 
-func (c Client) GetTransaction(id string) (*Transaction, error) {
+func (c Client) GetTransaction(ctx context.Context, id string) (*Transaction, error) {
 	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/transactions/%s", c.BaseUrl, id)
 
-	req, err := http.NewRequest("POST", apiUrl, nil)
-	if err != nil {
-		return nil, &InternalError{Message: err.Error()}
-	}
-
-	req.Header = c.constructAuthorizationHeader()
-	req.Header.Add("Content-Type", "application/json")
-	return c.executeApiReturnsTransaction(req)
+	return c.executeApiReturnsTransaction(ctx, apiUrl, "GET", nil)
 }
 
-func (c Client) CommitTransaction(id string) (*Transaction, error) {
+// CommitTransaction applies the transaction. A rejected commit is reported as a CommitFailedError;
+// use IsVersionConflict to detect a commit rejected because the configuration changed in the meantime.
+func (c Client) CommitTransaction(ctx context.Context, id string) (*Transaction, error) {
 	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/transactions/%s", c.BaseUrl, id)
 
-	req, err := http.NewRequest("PUT", apiUrl, nil)
+	transaction, err := c.executeApiReturnsTransaction(ctx, apiUrl, "PUT", nil)
 	if err != nil {
-		return nil, &InternalError{Message: err.Error()}
+		if apiErr := GetAPIError(err); apiErr != nil {
+			return nil, &CommitFailedError{Message: apiErr.Message, TransactionID: id, Err: err}
+		}
+		return nil, err
+	}
+
+	if transaction != nil && transaction.Status != nil && *transaction.Status == TRANSACTION_STATUS_FAILED {
+		return transaction, &CommitFailedError{Message: "transaction status is failed", TransactionID: id}
 	}
 
-	req.Header = c.constructAuthorizationHeader()
-	req.Header.Add("Content-Type", "application/json")
-	return c.executeApiReturnsTransaction(req)
+	return transaction, nil
 }
 
-func (c Client) CloseTransaction(id string) (*string, error) {
+func (c Client) CloseTransaction(ctx context.Context, id string) (*string, error) {
 	apiUrl := fmt.Sprintf("%s/v3/services/haproxy/transactions/%s", c.BaseUrl, id)
-	res, err := c.callApi(apiUrl, "DELETE", nil)
+	res, err := c.callApi(ctx, apiUrl, "DELETE", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -101,37 +114,80 @@ func (c Client) CloseTransaction(id string) (*string, error) {
 	return &responseText, err
 }
 
-func (c Client) executeApiReturnsTransaction(r *http.Request) (*Transaction, error) {
-	client := new(http.Client)
-	res, err := client.Do(r)
+// WithTransaction runs fn inside a new transaction created from the current configuration version.
+// The transaction is committed when fn returns nil, and closed when fn returns an error or panics.
+// When the commit fails because the configuration version moved (see IsVersionConflict), the whole unit
+// including fn is run again against the new version, up to the number of attempts set by WithTransactionAttempts.
+func (c Client) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) (*Transaction, error) {
+	attempts := c.transactionAttempts
+	if attempts <= 0 {
+		attempts = defaultTransactionAttempts
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		var committed *Transaction
+		committed, err = c.runTransaction(ctx, fn)
+		if !IsVersionConflict(err) {
+			return committed, err
+		}
+	}
+
+	return nil, err
+}
+
+func (c Client) runTransaction(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) (*Transaction, error) {
+	version, err := c.GetVersion(ctx)
 	if err != nil {
-		return nil, &InternalError{Message: err.Error()}
+		return nil, err
 	}
-	defer res.Body.Close()
 
-	resTxt, err := io.ReadAll(res.Body)
+	tx, err := c.CreateTransaction(ctx, *version)
 	if err != nil {
-		return nil, &InvalidResponseError{Message: err.Error()}
+		return nil, err
 	}
 
-	if len(string(resTxt)) == 0 {
-		return nil, nil
+	// the transaction is closed even when ctx is already canceled, so it does not linger on the server
+	closeTransaction := func() {
+		_ = tx.Close(context.WithoutCancel(ctx))
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			closeTransaction()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx, tx); err != nil {
+		closeTransaction()
+		return nil, err
 	}
 
-	if res.StatusCode == http.StatusUnauthorized {
-		return nil, &UnauthorizedError{Message: "unauthorized"}
-	} else if res.StatusCode == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "not found"}
-	} else if res.StatusCode/100 != 2 {
-		return nil, &UnknownError{Message: "unknown error"}
+	committed, err := tx.Commit(ctx)
+	if err != nil {
+		closeTransaction()
+		return nil, err
+	}
+
+	return committed, nil
+}
+
+func (c Client) executeApiReturnsTransaction(ctx context.Context, apiUrl string, method string, body io.Reader) (*Transaction, error) {
+	resTxt, header, err := c.callApiWithHeader(ctx, apiUrl, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(string(resTxt)) == 0 {
+		return nil, nil
 	}
 
 	var resResult Transaction
 	if err := json.Unmarshal(resTxt, &resResult); err != nil {
-		return nil, &InvalidResponseError{
-			Message: err.Error(),
-		}
+		return nil, &InvalidResponseError{Message: err.Error()}
 	}
+	resResult.ReloadId = header.Get(RELOAD_ID_HEADER)
 
 	return &resResult, nil
 }
@@ -0,0 +1,232 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeTransactions serves the transaction endpoints of the Data Plane API.
+// Every commit is answered with commitStatus and commitBody.
+type fakeTransactions struct {
+	commitStatus int
+	commitBody   string
+
+	mu      sync.Mutex
+	created int
+	commits int
+	closed  []string
+}
+
+func (f *fakeTransactions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/v3/services/haproxy/configuration/version":
+		fmt.Fprint(w, "7\n")
+	case r.URL.Path == "/v3/services/haproxy/transactions" && r.Method == http.MethodPost:
+		f.created++
+		fmt.Fprintf(w, `{"id":"tx-%d","status":"in_progress"}`, f.created)
+	case strings.HasPrefix(r.URL.Path, "/v3/services/haproxy/transactions/") && r.Method == http.MethodPut:
+		f.commits++
+		w.WriteHeader(f.commitStatus)
+		fmt.Fprint(w, f.commitBody)
+	case strings.HasPrefix(r.URL.Path, "/v3/services/haproxy/transactions/") && r.Method == http.MethodDelete:
+		f.closed = append(f.closed, strings.TrimPrefix(r.URL.Path, "/v3/services/haproxy/transactions/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeClient(t *testing.T, handler http.Handler, opts ...ClientOption) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestWithTransactionCommits(t *testing.T) {
+	fake := &fakeTransactions{commitStatus: http.StatusOK, commitBody: `{"id":"tx-1","status":"success"}`}
+	client := newFakeClient(t, fake)
+
+	calls := 0
+	committed, err := client.WithTransaction(context.Background(), func(ctx context.Context, tx *Tx) error {
+		calls++
+		if got := tx.id(); got != "tx-1" {
+			t.Errorf("transaction id = %q, want tx-1", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if committed == nil || committed.Status == nil || *committed.Status != TRANSACTION_STATUS_SUCCESS {
+		t.Errorf("committed = %+v, want status success", committed)
+	}
+	if calls != 1 || fake.commits != 1 || len(fake.closed) != 0 {
+		t.Errorf("calls = %d, commits = %d, closed = %v, want 1, 1, []", calls, fake.commits, fake.closed)
+	}
+}
+
+func TestWithTransactionRetriesOnVersionConflict(t *testing.T) {
+	for _, status := range []int{http.StatusConflict, http.StatusNotAcceptable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			fake := &fakeTransactions{commitStatus: status, commitBody: `{"code":409,"message":"version mismatch"}`}
+			client := newFakeClient(t, fake, WithTransactionAttempts(4))
+
+			calls := 0
+			_, err := client.WithTransaction(context.Background(), func(ctx context.Context, tx *Tx) error {
+				calls++
+				return nil
+			})
+			if !IsVersionConflict(err) {
+				t.Fatalf("err = %v, want a version conflict", err)
+			}
+
+			if calls != 4 || fake.created != 4 || fake.commits != 4 {
+				t.Errorf("calls = %d, created = %d, commits = %d, want 4 each", calls, fake.created, fake.commits)
+			}
+			if len(fake.closed) != 4 {
+				t.Errorf("closed = %v, want every failed transaction closed", fake.closed)
+			}
+		})
+	}
+}
+
+func TestWithTransactionDoesNotRetryOtherCommitFailures(t *testing.T) {
+	fake := &fakeTransactions{commitStatus: http.StatusBadRequest, commitBody: `{"code":400,"message":"invalid backend"}`}
+	client := newFakeClient(t, fake)
+
+	calls := 0
+	_, err := client.WithTransaction(context.Background(), func(ctx context.Context, tx *Tx) error {
+		calls++
+		return nil
+	})
+	if !IsCommitFailed(err) || IsVersionConflict(err) || !IsBadRequest(err) {
+		t.Fatalf("err = %v, want a commit failure wrapping a bad request", err)
+	}
+
+	if calls != 1 || fake.commits != 1 {
+		t.Errorf("calls = %d, commits = %d, want 1, 1", calls, fake.commits)
+	}
+	if len(fake.closed) != 1 || fake.closed[0] != "tx-1" {
+		t.Errorf("closed = %v, want [tx-1]", fake.closed)
+	}
+}
+
+func TestWithTransactionClosesWhenFnFails(t *testing.T) {
+	fake := &fakeTransactions{commitStatus: http.StatusOK}
+	client := newFakeClient(t, fake)
+
+	fnErr := errors.New("fn failed")
+	_, err := client.WithTransaction(context.Background(), func(ctx context.Context, tx *Tx) error {
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Fatalf("err = %v, want %v", err, fnErr)
+	}
+
+	if fake.commits != 0 || len(fake.closed) != 1 {
+		t.Errorf("commits = %d, closed = %v, want 0, [tx-1]", fake.commits, fake.closed)
+	}
+}
+
+func TestWithTransactionClosesAndRepanics(t *testing.T) {
+	fake := &fakeTransactions{commitStatus: http.StatusOK}
+	client := newFakeClient(t, fake)
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, want boom", p)
+		}
+		if fake.commits != 0 || len(fake.closed) != 1 {
+			t.Errorf("commits = %d, closed = %v, want 0, [tx-1]", fake.commits, fake.closed)
+		}
+	}()
+
+	_, _ = client.WithTransaction(context.Background(), func(ctx context.Context, tx *Tx) error {
+		panic("boom")
+	})
+	t.Error("WithTransaction returned after fn panicked")
+}
+
+func TestWithTransactionClosesAfterCancel(t *testing.T) {
+	fake := &fakeTransactions{commitStatus: http.StatusOK}
+	client := newFakeClient(t, fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := client.WithTransaction(ctx, func(ctx context.Context, tx *Tx) error {
+		cancel()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+
+	if len(fake.closed) != 1 {
+		t.Errorf("closed = %v, want the transaction closed despite the canceled context", fake.closed)
+	}
+}
+
+func TestResponseErrors(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		is      func(error) bool
+		code    int
+		message string
+	}{
+		{http.StatusBadRequest, `{"code":400,"message":"invalid name"}`, IsBadRequest, 400, "invalid name"},
+		{http.StatusUnauthorized, `{"code":401,"message":"unauthorized"}`, IsUnauthorized, 401, "unauthorized"},
+		{http.StatusNotFound, `{"code":404,"message":"missing"}`, IsNotFound, 404, "missing"},
+		{http.StatusNotAcceptable, `{"code":406,"message":"outdated"}`, IsNotAcceptable, 406, "outdated"},
+		{http.StatusConflict, `{"code":409,"message":"version mismatch"}`, IsConflict, 409, "version mismatch"},
+		{http.StatusUnprocessableEntity, `{"code":422,"message":"bad field"}`, IsUnprocessableEntity, 422, "bad field"},
+		{http.StatusInternalServerError, `{"code":500,"message":"broken"}`, IsInternalServerError, 500, "broken"},
+		{http.StatusServiceUnavailable, `{"code":503,"message":"busy"}`, IsServiceUnavailable, 503, "busy"},
+		{http.StatusTeapot, "not json\n", IsUnknownError, 0, "not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			client := newFakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+
+			_, err := client.GetBackend(context.Background(), "web", "tx-1")
+			if !tt.is(err) {
+				t.Fatalf("err = %#v, not matched by its Is helper", err)
+			}
+
+			apiErr := GetAPIError(err)
+			if apiErr == nil {
+				t.Fatalf("GetAPIError(%v) = nil", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Code != tt.code || apiErr.Message != tt.message {
+				t.Errorf("APIError = {%d %d %q}, want {%d %d %q}", apiErr.StatusCode, apiErr.Code, apiErr.Message, tt.status, tt.code, tt.message)
+			}
+			if apiErr.Method != http.MethodGet || !strings.Contains(apiErr.URL, "/configuration/backends/web?transaction_id=tx-1") {
+				t.Errorf("APIError request = %s %s", apiErr.Method, apiErr.URL)
+			}
+			if GetHTTPStatusCode(err) != tt.status {
+				t.Errorf("GetHTTPStatusCode = %d, want %d", GetHTTPStatusCode(err), tt.status)
+			}
+		})
+	}
+}
@@ -1,22 +1,34 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	
+	"time"
+
 	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
 )
 
+var backendName = "example-backend"
+
 func main() {
 	// Create a client
-	client := v3.Client{
-		BaseUrl:    "http://localhost:5555",
-		Credential: "YWRtaW46YWRtaW4=", // base64 encoded "admin:admin"
+	client, err := v3.NewClient(
+		"http://localhost:5555",
+		v3.WithBasicAuth("admin", "admin"),
+		v3.WithTimeout(10*time.Second),
+	)
+	if err != nil {
+		log.Fatal("Failed to create client:", err)
 	}
 
+	// Bound every call so a hung Data Plane API cannot block forever
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
 	// Example 1: Handling specific error types
-	backends, err := client.ListBackends("non-existent-transaction")
+	backends, err := client.ListBackends(ctx, "non-existent-transaction")
 	if err != nil {
 		switch {
 		case v3.IsNotFound(err):
@@ -25,6 +37,8 @@ func main() {
 			fmt.Println("Authentication failed:", err)
 		case v3.IsConflict(err):
 			fmt.Println("Conflict occurred:", err)
+		case v3.IsCanceled(err):
+			fmt.Println("Request canceled or timed out:", err)
 		case v3.IsUnknownError(err):
 			// Get HTTP status code for unknown errors
 			statusCode := v3.GetHTTPStatusCode(err)
@@ -36,22 +50,24 @@ func main() {
 	}
 
 	// Example 2: Transaction commit with error handling
-	version, err := client.GetVersion()
+	version, err := client.GetVersion(ctx)
 	if err != nil {
 		log.Fatal("Failed to get version:", err)
 	}
 
-	transaction, err := client.CreateTransaction(*version)
+	tx, err := client.CreateTransaction(ctx, *version)
 	if err != nil {
 		log.Fatal("Failed to create transaction:", err)
 	}
 
-	// ... do some operations ...
+	// ... do some operations, e.g. tx.AddBackend(ctx, backend) ...
 
 	// Commit transaction
-	_, err = client.CommitTransaction(*transaction.Id)
+	_, err = tx.Commit(ctx)
 	if err != nil {
-		if v3.IsCommitFailed(err) {
+		if v3.IsVersionConflict(err) {
+			fmt.Println("Configuration changed since the transaction was created, retry:", err)
+		} else if v3.IsCommitFailed(err) {
 			fmt.Println("Transaction commit failed:", err)
 			// You can extract the transaction ID from CommitFailedError
 			var commitErr *v3.CommitFailedError
@@ -65,7 +81,7 @@ func main() {
 	}
 
 	// Example 3: Type assertions for detailed error information
-	backend, err := client.GetBackend("non-existent", "some-transaction")
+	backend, err := client.GetBackend(ctx, "non-existent", "some-transaction")
 	if err != nil {
 		// Type assertion to get detailed error info
 		var unknownErr *v3.UnknownError
@@ -73,8 +89,49 @@ func main() {
 			fmt.Printf("HTTP Status: %d\n", unknownErr.StatusCode)
 			fmt.Printf("Error Message: %s\n", unknownErr.Message)
 		}
+
+		// Every API error carries the decoded payload and the failed request
+		if apiErr := v3.GetAPIError(err); apiErr != nil {
+			fmt.Printf("%s %s -> %d (code %d): %s\n", apiErr.Method, apiErr.URL, apiErr.StatusCode, apiErr.Code, apiErr.Message)
+		}
+	}
+
+	// Example 4: Let WithTransaction commit, roll back and retry on version conflicts
+	_, err = client.WithTransaction(ctx, func(ctx context.Context, tx *v3.Tx) error {
+		_, err := tx.AddBackend(ctx, v3.Backend{Name: &backendName, Mode: v3.BACKEND_MODE_TCP})
+		return err
+	})
+	if err != nil {
+		fmt.Println("Transaction failed:", err)
+	}
+
+	// Example 5: Apply a single change at a configuration version, without a transaction
+	if version, err := client.GetVersion(ctx); err == nil {
+		serverName, address := "web1", "10.0.0.1"
+		applied, err := client.ApplyAtVersion(ctx, *version, true, func(ctx context.Context, cfg *v3.Configuration) error {
+			_, err := cfg.AddServer(ctx, backendName, v3.Server{Name: &serverName, Address: &address})
+			return err
+		})
+		if v3.IsConflict(err) {
+			fmt.Println("Configuration version moved, fetch it again and retry:", err)
+		}
+		for _, reloadId := range applied.ReloadIds {
+			if _, err := client.WaitForReload(ctx, reloadId); err != nil {
+				fmt.Println("Reload failed:", err)
+			}
+		}
+	}
+
+	// Example 6: Wait until HAProxy has actually picked up a committed transaction
+	committed, err := client.WithTransaction(ctx, func(ctx context.Context, tx *v3.Tx) error {
+		return tx.DeleteBackend(ctx, backendName)
+	})
+	if err == nil && committed.ReloadId != "" {
+		if _, err := client.WaitForReload(ctx, committed.ReloadId); v3.IsReloadFailed(err) {
+			fmt.Println("HAProxy rejected the new configuration:", err)
+		}
 	}
 
 	_ = backends
 	_ = backend
-}
\ No newline at end of file
+}